@@ -22,7 +22,10 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SOAChatClient interface {
-	Connect(ctx context.Context, in *ConnectIn, opts ...grpc.CallOption) (*ConnectOut, error)
+	// messages of a channel, the stream ends with an error once the player
+	// loses access to the channel. The kept messages sent after since_id are
+	// replayed first.
+	Subscribe(ctx context.Context, in *SubscribeIn, opts ...grpc.CallOption) (SOAChat_SubscribeClient, error)
 	SendMessage(ctx context.Context, in *SendMessageIn, opts ...grpc.CallOption) (*SendMessageOut, error)
 }
 
@@ -34,13 +37,36 @@ func NewSOAChatClient(cc grpc.ClientConnInterface) SOAChatClient {
 	return &sOAChatClient{cc}
 }
 
-func (c *sOAChatClient) Connect(ctx context.Context, in *ConnectIn, opts ...grpc.CallOption) (*ConnectOut, error) {
-	out := new(ConnectOut)
-	err := c.cc.Invoke(ctx, "/SOAChat/Connect", in, out, opts...)
+func (c *sOAChatClient) Subscribe(ctx context.Context, in *SubscribeIn, opts ...grpc.CallOption) (SOAChat_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SOAChat_ServiceDesc.Streams[0], "/SOAChat/Subscribe", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &sOAChatSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SOAChat_SubscribeClient interface {
+	Recv() (*Message, error)
+	grpc.ClientStream
+}
+
+type sOAChatSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *sOAChatSubscribeClient) Recv() (*Message, error) {
+	m := new(Message)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
 func (c *sOAChatClient) SendMessage(ctx context.Context, in *SendMessageIn, opts ...grpc.CallOption) (*SendMessageOut, error) {
@@ -56,7 +82,10 @@ func (c *sOAChatClient) SendMessage(ctx context.Context, in *SendMessageIn, opts
 // All implementations must embed UnimplementedSOAChatServer
 // for forward compatibility
 type SOAChatServer interface {
-	Connect(context.Context, *ConnectIn) (*ConnectOut, error)
+	// messages of a channel, the stream ends with an error once the player
+	// loses access to the channel. The kept messages sent after since_id are
+	// replayed first.
+	Subscribe(*SubscribeIn, SOAChat_SubscribeServer) error
 	SendMessage(context.Context, *SendMessageIn) (*SendMessageOut, error)
 	mustEmbedUnimplementedSOAChatServer()
 }
@@ -65,8 +94,8 @@ type SOAChatServer interface {
 type UnimplementedSOAChatServer struct {
 }
 
-func (UnimplementedSOAChatServer) Connect(context.Context, *ConnectIn) (*ConnectOut, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Connect not implemented")
+func (UnimplementedSOAChatServer) Subscribe(*SubscribeIn, SOAChat_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
 func (UnimplementedSOAChatServer) SendMessage(context.Context, *SendMessageIn) (*SendMessageOut, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendMessage not implemented")
@@ -84,22 +113,25 @@ func RegisterSOAChatServer(s grpc.ServiceRegistrar, srv SOAChatServer) {
 	s.RegisterService(&SOAChat_ServiceDesc, srv)
 }
 
-func _SOAChat_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ConnectIn)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(SOAChatServer).Connect(ctx, in)
+func _SOAChat_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeIn)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/SOAChat/Connect",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(SOAChatServer).Connect(ctx, req.(*ConnectIn))
-	}
-	return interceptor(ctx, in, info, handler)
+	return srv.(SOAChatServer).Subscribe(m, &sOAChatSubscribeServer{stream})
+}
+
+type SOAChat_SubscribeServer interface {
+	Send(*Message) error
+	grpc.ServerStream
+}
+
+type sOAChatSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *sOAChatSubscribeServer) Send(m *Message) error {
+	return x.ServerStream.SendMsg(m)
 }
 
 func _SOAChat_SendMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
@@ -127,15 +159,17 @@ var SOAChat_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "SOAChat",
 	HandlerType: (*SOAChatServer)(nil),
 	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Connect",
-			Handler:    _SOAChat_Connect_Handler,
-		},
 		{
 			MethodName: "SendMessage",
 			Handler:    _SOAChat_SendMessage_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _SOAChat_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "service.proto",
 }
@@ -0,0 +1,97 @@
+package broker
+
+import (
+	"context"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// AMQP declares a fanout exchange per topic and a queue per subscription.
+type AMQP struct {
+	channel *amqp.Channel
+}
+
+func NewAMQP(ch *amqp.Channel) *AMQP {
+	return &AMQP{
+		channel: ch,
+	}
+}
+
+func (b *AMQP) Publish(ctx context.Context, topic string, body []byte) error {
+	// publishing to a missing exchange would close the AMQP channel, and a
+	// topic may get its first message before anybody subscribes to it
+	if err := b.declare(topic); err != nil {
+		return err
+	}
+
+	return b.channel.PublishWithContext(
+		ctx,
+		topic,
+		"",
+		false,
+		false,
+		amqp.Publishing{
+			ContentType: "text/plain",
+			Body:        body,
+		},
+	)
+}
+
+func (b *AMQP) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	if err := b.declare(topic); err != nil {
+		return nil, err
+	}
+
+	q, err := b.channel.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := b.channel.QueueBind(q.Name, "", topic, false, nil); err != nil {
+		return nil, err
+	}
+
+	// the queue name is unique, so it doubles as the consumer tag
+	deliveries, err := b.channel.Consume(q.Name, q.Name, true, false, false, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan []byte)
+
+	go func() {
+		defer close(out)
+		defer b.channel.Cancel(q.Name, false)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case delivery, ok := <-deliveries:
+				if !ok {
+					return
+				}
+
+				select {
+				case out <- delivery.Body:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (b *AMQP) declare(topic string) error {
+	return b.channel.ExchangeDeclare(
+		topic,
+		"fanout",
+		false,
+		false,
+		false,
+		false,
+		nil,
+	)
+}
@@ -0,0 +1,20 @@
+// Package broker fans chat messages out to their subscribers.
+package broker
+
+import (
+	"context"
+)
+
+// Broker delivers every message published to a topic to all of its current
+// subscribers.
+type Broker interface {
+	// Publish does not wait for the subscribers to read the message. A
+	// subscriber that falls too far behind may be dropped instead, it then
+	// gets the messages it has already been handed and its channel is
+	// closed.
+	Publish(ctx context.Context, topic string, body []byte) error
+	// Subscribe returns the messages published to the topic from now on. The
+	// channel is closed once ctx is done or the broker drops the
+	// subscription.
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
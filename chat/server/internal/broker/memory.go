@@ -0,0 +1,99 @@
+package broker
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriptionBuffer is how many messages a subscriber may fall behind
+// before it is dropped.
+const subscriptionBuffer = 64
+
+// Memory is an in-process broker for running the chat without RabbitMQ,
+// e.g. in development and tests.
+type Memory struct {
+	mu          sync.Mutex
+	subscribers map[string]map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	ch chan []byte
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		subscribers: map[string]map[*subscriber]struct{}{},
+	}
+}
+
+// Publish drops the subscribers whose buffer is full, so that a slow reader
+// does not hold up the others.
+func (b *Memory) Publish(ctx context.Context, topic string, body []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers[topic] {
+		select {
+		case sub.ch <- body:
+		default:
+			// nothing is sent to ch once it is removed under the lock
+			b.remove(topic, sub)
+			close(sub.ch)
+		}
+	}
+
+	return nil
+}
+
+func (b *Memory) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	sub := &subscriber{
+		ch: make(chan []byte, subscriptionBuffer),
+	}
+
+	b.mu.Lock()
+	if b.subscribers[topic] == nil {
+		b.subscribers[topic] = map[*subscriber]struct{}{}
+	}
+	b.subscribers[topic][sub] = struct{}{}
+	b.mu.Unlock()
+
+	out := make(chan []byte)
+
+	go func() {
+		defer close(out)
+		defer b.unsubscribe(topic, sub)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case body, ok := <-sub.ch:
+				if !ok {
+					return
+				}
+
+				select {
+				case out <- body:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+func (b *Memory) unsubscribe(topic string, sub *subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.remove(topic, sub)
+}
+
+func (b *Memory) remove(topic string, sub *subscriber) {
+	delete(b.subscribers[topic], sub)
+	if len(b.subscribers[topic]) == 0 {
+		delete(b.subscribers, topic)
+	}
+}
@@ -0,0 +1,57 @@
+package broker
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	b := NewMemory()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	slow, err := b.Subscribe(ctx, "1.public")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fast, err := b.Subscribe(ctx, "1.public")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the fast subscriber keeps up while the slow one reads nothing, which
+	// does not hold up publishing
+	for i := 0; i < subscriptionBuffer*2; i++ {
+		if err := b.Publish(ctx, "1.public", []byte(strconv.Itoa(i))); err != nil {
+			t.Fatal(err)
+		}
+
+		select {
+		case body := <-fast:
+			if string(body) != strconv.Itoa(i) {
+				t.Fatalf("got %s, want %d", body, i)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("message %d is not delivered", i)
+		}
+	}
+
+	// the slow subscriber gets the messages it was handed, in order, and is
+	// closed after them
+	n := 0
+	for body := range slow {
+		if string(body) != strconv.Itoa(n) {
+			t.Fatalf("got %s, want %d", body, n)
+		}
+
+		n++
+	}
+
+	if n < subscriptionBuffer || n >= subscriptionBuffer*2 {
+		t.Fatalf("the slow subscriber got %d messages before it was dropped", n)
+	}
+}
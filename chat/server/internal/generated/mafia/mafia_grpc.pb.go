@@ -0,0 +1,141 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: mafia.proto
+
+package mafia
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SOAMafiaClient is the client API for SOAMafia service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SOAMafiaClient interface {
+	Login(ctx context.Context, in *LoginIn, opts ...grpc.CallOption) (*LoginOut, error)
+	GetMembership(ctx context.Context, in *MembershipIn, opts ...grpc.CallOption) (*Membership, error)
+}
+
+type sOAMafiaClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSOAMafiaClient(cc grpc.ClientConnInterface) SOAMafiaClient {
+	return &sOAMafiaClient{cc}
+}
+
+func (c *sOAMafiaClient) Login(ctx context.Context, in *LoginIn, opts ...grpc.CallOption) (*LoginOut, error) {
+	out := new(LoginOut)
+	err := c.cc.Invoke(ctx, "/SOAMafia/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sOAMafiaClient) GetMembership(ctx context.Context, in *MembershipIn, opts ...grpc.CallOption) (*Membership, error) {
+	out := new(Membership)
+	err := c.cc.Invoke(ctx, "/SOAMafia/GetMembership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SOAMafiaServer is the server API for SOAMafia service.
+// All implementations must embed UnimplementedSOAMafiaServer
+// for forward compatibility
+type SOAMafiaServer interface {
+	Login(context.Context, *LoginIn) (*LoginOut, error)
+	GetMembership(context.Context, *MembershipIn) (*Membership, error)
+	mustEmbedUnimplementedSOAMafiaServer()
+}
+
+// UnimplementedSOAMafiaServer must be embedded to have forward compatible implementations.
+type UnimplementedSOAMafiaServer struct {
+}
+
+func (UnimplementedSOAMafiaServer) Login(context.Context, *LoginIn) (*LoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedSOAMafiaServer) GetMembership(context.Context, *MembershipIn) (*Membership, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMembership not implemented")
+}
+func (UnimplementedSOAMafiaServer) mustEmbedUnimplementedSOAMafiaServer() {}
+
+// UnsafeSOAMafiaServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SOAMafiaServer will
+// result in compilation errors.
+type UnsafeSOAMafiaServer interface {
+	mustEmbedUnimplementedSOAMafiaServer()
+}
+
+func RegisterSOAMafiaServer(s grpc.ServiceRegistrar, srv SOAMafiaServer) {
+	s.RegisterService(&SOAMafia_ServiceDesc, srv)
+}
+
+func _SOAMafia_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SOAMafiaServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SOAMafia/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SOAMafiaServer).Login(ctx, req.(*LoginIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SOAMafia_GetMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembershipIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SOAMafiaServer).GetMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SOAMafia/GetMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SOAMafiaServer).GetMembership(ctx, req.(*MembershipIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SOAMafia_ServiceDesc is the grpc.ServiceDesc for SOAMafia service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SOAMafia_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "SOAMafia",
+	HandlerType: (*SOAMafiaServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Login",
+			Handler:    _SOAMafia_Login_Handler,
+		},
+		{
+			MethodName: "GetMembership",
+			Handler:    _SOAMafia_GetMembership_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mafia.proto",
+}
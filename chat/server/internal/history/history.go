@@ -0,0 +1,146 @@
+// Package history keeps the recent messages of the chat channels, so that the
+// players who join late or reconnect can catch up.
+package history
+
+import (
+	"sync"
+	"time"
+)
+
+// evictionInterval is how often Run looks for idle sessions.
+const evictionInterval = time.Minute
+
+type Message struct {
+	// ID grows with every message and is never reused, also after the
+	// history is dropped or the server restarts.
+	ID       int64     `json:"id"`
+	Username string    `json:"username"`
+	Text     string    `json:"text"`
+	SentAt   time.Time `json:"sent_at"`
+}
+
+// History keeps the last messages of every topic of a session, older ones are
+// dropped. A session is dropped as a whole once its game is over or nobody
+// has written to it for a while.
+type History struct {
+	limit int
+	ttl   time.Duration
+
+	mu sync.Mutex
+	// lastID is shared by every topic. It starts at the time the history is
+	// created in microseconds, so that the IDs of a restarted server are
+	// greater than the ones the clients have seen before.
+	lastID   int64
+	sessions map[int64]*session
+}
+
+type session struct {
+	topics     map[string]*topic
+	lastActive time.Time
+}
+
+type topic struct {
+	messages []Message
+}
+
+// New keeps up to limit messages per topic and forgets the sessions idle for
+// ttl.
+func New(limit int, ttl time.Duration) *History {
+	return &History{
+		limit:    limit,
+		ttl:      ttl,
+		lastID:   time.Now().UnixMicro(),
+		sessions: map[int64]*session{},
+	}
+}
+
+// Append stores the message, assigning it the next ID and the current time.
+func (h *History) Append(sessionID int64, name string, username string, text string) Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.sessions[sessionID]
+	if !ok {
+		s = &session{topics: map[string]*topic{}}
+		h.sessions[sessionID] = s
+	}
+
+	t, ok := s.topics[name]
+	if !ok {
+		t = &topic{}
+		s.topics[name] = t
+	}
+
+	h.lastID++
+	msg := Message{
+		ID:       h.lastID,
+		Username: username,
+		Text:     text,
+		SentAt:   time.Now(),
+	}
+
+	s.lastActive = msg.SentAt
+
+	t.messages = append(t.messages, msg)
+	if len(t.messages) > h.limit {
+		t.messages = t.messages[len(t.messages)-h.limit:]
+	}
+
+	return msg
+}
+
+// Since returns the kept messages of the topic with IDs greater than id,
+// oldest first.
+func (h *History) Since(sessionID int64, name string, id int64) []Message {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.sessions[sessionID]
+	if !ok {
+		return nil
+	}
+
+	t, ok := s.topics[name]
+	if !ok {
+		return nil
+	}
+
+	messages := []Message{}
+	for _, msg := range t.messages {
+		if msg.ID > id {
+			messages = append(messages, msg)
+		}
+	}
+
+	return messages
+}
+
+// Forget drops every topic of the session.
+func (h *History) Forget(sessionID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.sessions, sessionID)
+}
+
+// Run evicts the idle sessions periodically.
+func (h *History) Run() {
+	ticker := time.NewTicker(evictionInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		h.Evict(now)
+	}
+}
+
+// Evict drops the sessions nobody has written to for the ttl.
+func (h *History) Evict(now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sessionID, s := range h.sessions {
+		if now.Sub(s.lastActive) >= h.ttl {
+			delete(h.sessions, sessionID)
+		}
+	}
+}
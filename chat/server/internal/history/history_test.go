@@ -0,0 +1,37 @@
+package history
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvict(t *testing.T) {
+	h := New(10, time.Hour)
+
+	h.Append(1, "1.public", "alice", "hi")
+	h.Append(2, "2.public", "bob", "hi")
+
+	h.Evict(time.Now().Add(time.Minute))
+
+	if got := h.Since(1, "1.public", 0); len(got) != 1 {
+		t.Fatalf("active session: got %v", got)
+	}
+
+	h.Evict(time.Now().Add(time.Hour))
+
+	if len(h.sessions) != 0 {
+		t.Fatalf("idle sessions are kept: %v", h.sessions)
+	}
+}
+
+func TestIDsAfterRestart(t *testing.T) {
+	before := New(10, time.Hour).Append(1, "1.public", "alice", "hi")
+
+	time.Sleep(time.Millisecond)
+
+	// a client keeps its cursor across a restart of the server
+	after := New(10, time.Hour).Append(1, "1.public", "alice", "hi")
+	if after.ID <= before.ID {
+		t.Fatalf("the restarted history reuses IDs: %d after %d", after.ID, before.ID)
+	}
+}
@@ -0,0 +1,70 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/generated/mafia"
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/generated/proto"
+)
+
+// canRead checks that the player may read the channel. Once the game is over
+// every channel is open to all players.
+func canRead(channel proto.Channel, m *mafia.Membership) error {
+	if m.Finished {
+		return nil
+	}
+
+	switch channel {
+	case proto.Channel_CHANNEL_PUBLIC:
+		return nil
+	case proto.Channel_CHANNEL_MAFIA:
+		if m.Role != mafia.Role_MAFIA || !m.Alive {
+			return status.Error(codes.PermissionDenied, "only alive mafia can use the mafia chat")
+		}
+
+		return nil
+	case proto.Channel_CHANNEL_DEAD:
+		if m.Alive {
+			return status.Error(codes.PermissionDenied, "only eliminated players can use the dead chat")
+		}
+
+		return nil
+	default:
+		return status.Error(codes.InvalidArgument, "unknown channel")
+	}
+}
+
+// canPost checks that the player may write to the channel now. Civilians are
+// silent at night and the mafia talks only at night.
+func canPost(channel proto.Channel, m *mafia.Membership) error {
+	if err := canRead(channel, m); err != nil || m.Finished {
+		return err
+	}
+
+	switch channel {
+	case proto.Channel_CHANNEL_PUBLIC:
+		if !m.Alive {
+			return status.Error(codes.PermissionDenied, "eliminated players can not use the public chat")
+		}
+
+		if m.Night {
+			return status.Error(codes.FailedPrecondition, "the public chat is closed at night")
+		}
+	case proto.Channel_CHANNEL_MAFIA:
+		if !m.Night {
+			return status.Error(codes.FailedPrecondition, "the mafia chat is open only at night")
+		}
+	}
+
+	return nil
+}
+
+// topicName is the broker topic of a channel, e.g. "1.public".
+func topicName(sessionID int64, channel proto.Channel) string {
+	name := strings.ToLower(strings.TrimPrefix(channel.String(), "CHANNEL_"))
+	return fmt.Sprintf("%d.%s", sessionID, name)
+}
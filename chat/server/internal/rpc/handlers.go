@@ -3,69 +3,176 @@ package rpc
 import (
 	"context"
 	"encoding/json"
-	"fmt"
+	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/broker"
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/generated/mafia"
 	"github.com/mcherdakov/soa-mafia/chat/server/internal/generated/proto"
-	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/history"
 )
 
+// accessTTL is how long the access of a subscriber is trusted before the game
+// server is asked again, so that a busy channel does not cost a game server
+// call per message and reader.
+const accessTTL = time.Second
+
 type SOAChatServer struct {
 	proto.UnimplementedSOAChatServer
 
-	channel *amqp.Channel
+	broker  broker.Broker
+	history *history.History
+	// mafia is the game server, which knows the players of the sessions.
+	mafia mafia.SOAMafiaClient
 }
 
-func NewSOAChatServer(ch *amqp.Channel) *SOAChatServer {
+func NewSOAChatServer(b broker.Broker, h *history.History, mafiaClient mafia.SOAMafiaClient) *SOAChatServer {
 	return &SOAChatServer{
-		channel: ch,
+		broker:  b,
+		history: h,
+		mafia:   mafiaClient,
 	}
 }
 
-func (s *SOAChatServer) Connect(ctx context.Context, in *proto.ConnectIn) (*proto.ConnectOut, error) {
-	queueName := fmt.Sprint(in.SessionId)
-
-	err := s.channel.ExchangeDeclare(
-		queueName,
-		"fanout",
-		false,
-		false,
-		false,
-		false,
-		nil,
-	)
+// Subscribe consumes the channel from the broker and streams it to the
+// player, after replaying the kept messages the player has missed. Access is
+// checked again before delivering a message once accessTTL has passed, since
+// a player may lose it during the game, e.g. a killed mafia member.
+func (s *SOAChatServer) Subscribe(in *proto.SubscribeIn, srv proto.SOAChat_SubscribeServer) error {
+	ctx := srv.Context()
+
+	m, err := s.member(ctx, in.SessionId)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return &proto.ConnectOut{
-		Topic: queueName,
-	}, nil
-}
+	if err := canRead(in.Channel, m); err != nil {
+		return err
+	}
+
+	checked := time.Now()
+
+	topic := topicName(in.SessionId, in.Channel)
+
+	deliveries, err := s.broker.Subscribe(ctx, topic)
+	if err != nil {
+		return err
+	}
+
+	// a channel may stay silent for long, the header tells the player right
+	// away that they are let in
+	if err := srv.SendHeader(metadata.Pairs("channel", topic)); err != nil {
+		return err
+	}
+
+	if m.Finished {
+		s.history.Forget(in.SessionId)
+	}
+
+	// the history is read after subscribing, so a message sent in between
+	// comes both ways and only its replay is sent
+	lastID := in.SinceId
+	for _, msg := range s.history.Since(in.SessionId, topic, in.SinceId) {
+		if err := srv.Send(toProto(msg, in.Channel)); err != nil {
+			return err
+		}
+
+		lastID = msg.ID
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case body, ok := <-deliveries:
+			if !ok {
+				// the subscription also ends when the player leaves
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				return status.Error(codes.Unavailable, "broker closed the subscription")
+			}
+
+			msg := history.Message{}
+			if err := json.Unmarshal(body, &msg); err != nil {
+				return err
+			}
 
-type Message struct {
-	Username string `json:"username"`
-	Text     string `json:"text"`
+			// messages sent after the game are not kept and have no ID
+			if msg.ID != 0 && msg.ID <= lastID {
+				continue
+			}
+
+			if time.Since(checked) >= accessTTL {
+				m, err := s.member(ctx, in.SessionId)
+				if err != nil {
+					return err
+				}
+
+				if err := canRead(in.Channel, m); err != nil {
+					return err
+				}
+
+				checked = time.Now()
+			}
+
+			if err := srv.Send(toProto(msg, in.Channel)); err != nil {
+				return err
+			}
+		}
+	}
 }
 
 func (s *SOAChatServer) SendMessage(ctx context.Context, in *proto.SendMessageIn) (*proto.SendMessageOut, error) {
-	body, err := json.Marshal(Message{in.Username, in.Text})
+	m, err := s.member(ctx, in.SessionId)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.channel.Publish(
-		fmt.Sprint(in.SessionId),
-		"",
-		false,
-		false,
-		amqp.Publishing{
-			ContentType: "text/plain",
-			Body:        body,
-		},
-	)
+	if err := canPost(in.Channel, m); err != nil {
+		return nil, err
+	}
+
+	topic := topicName(in.SessionId, in.Channel)
+
+	body, err := json.Marshal(s.keep(in.SessionId, topic, m, in.Text))
 	if err != nil {
 		return nil, err
 	}
 
+	if err := s.broker.Publish(ctx, topic, body); err != nil {
+		return nil, err
+	}
+
 	return &proto.SendMessageOut{}, nil
 }
+
+// keep stores the message in the history of the session. Once the game is
+// over the history is dropped and later messages are only relayed.
+func (s *SOAChatServer) keep(sessionID int64, topic string, m *mafia.Membership, text string) history.Message {
+	if m.Finished {
+		s.history.Forget(sessionID)
+
+		return history.Message{
+			Username: m.Username,
+			Text:     text,
+			SentAt:   time.Now(),
+		}
+	}
+
+	return s.history.Append(sessionID, topic, m.Username, text)
+}
+
+func toProto(msg history.Message, channel proto.Channel) *proto.Message {
+	return &proto.Message{
+		Username: msg.Username,
+		Text:     msg.Text,
+		Channel:  channel,
+		Id:       msg.ID,
+		SentAt:   msg.SentAt.UnixMilli(),
+	}
+}
@@ -0,0 +1,418 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	protobuf "google.golang.org/protobuf/proto"
+
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/broker"
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/generated/mafia"
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/history"
+)
+
+const (
+	sessionID    = 1
+	historyLimit = 2
+	waitTimeout  = time.Second * 5
+)
+
+// fakeMafia is a game server that knows the players by their tokens, which
+// are the usernames.
+type fakeMafia struct {
+	mu      sync.Mutex
+	members map[string]*mafia.Membership
+	// calls counts the membership lookups of every player.
+	calls map[string]int
+}
+
+func (f *fakeMafia) Login(context.Context, *mafia.LoginIn, ...grpc.CallOption) (*mafia.LoginOut, error) {
+	return nil, status.Error(codes.Unimplemented, "not used by the chat server")
+}
+
+func (f *fakeMafia) GetMembership(ctx context.Context, in *mafia.MembershipIn, _ ...grpc.CallOption) (*mafia.Membership, error) {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	username := md.Get("authorization")[0][len("Bearer "):]
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls[username]++
+
+	m, ok := f.members[username]
+	if !ok || in.SessionId != sessionID {
+		return &mafia.Membership{Username: username}, nil
+	}
+
+	membership := protobuf.Clone(m).(*mafia.Membership)
+	membership.Username = username
+	membership.Member = true
+
+	return membership, nil
+}
+
+func (f *fakeMafia) update(username string, change func(m *mafia.Membership)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	change(f.members[username])
+}
+
+func (f *fakeMafia) lookups(username string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.calls[username]
+}
+
+// start runs a chat server on the in-memory broker and returns a client of
+// it.
+func start(t *testing.T, members map[string]*mafia.Membership) (proto.SOAChatClient, *fakeMafia) {
+	t.Helper()
+
+	return startWith(t, members, history.New(historyLimit, time.Hour))
+}
+
+// startWith runs a chat server that keeps the messages in h.
+func startWith(t *testing.T, members map[string]*mafia.Membership, h *history.History) (proto.SOAChatClient, *fakeMafia) {
+	t.Helper()
+
+	game := &fakeMafia{members: members, calls: map[string]int{}}
+
+	s := grpc.NewServer()
+	proto.RegisterSOAChatServer(s, NewSOAChatServer(broker.NewMemory(), h, game))
+
+	listener := bufconn.Listen(1 << 20)
+	go s.Serve(listener)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+	})
+
+	return proto.NewSOAChatClient(conn), game
+}
+
+func as(username string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+username)
+}
+
+// subscribe returns once the server has let the player in, so that the
+// messages sent afterwards reach them. The messages after sinceID are
+// replayed.
+func subscribe(t *testing.T, client proto.SOAChatClient, username string, channel proto.Channel, sinceID int64) proto.SOAChat_SubscribeClient {
+	t.Helper()
+
+	stream, err := client.Subscribe(as(username), &proto.SubscribeIn{
+		SessionId: sessionID,
+		Channel:   channel,
+		SinceId:   sinceID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if header, err := stream.Header(); err != nil || len(header) == 0 {
+		_, err := stream.Recv()
+		t.Fatalf("%s: subscribe %s: %v", username, channel, err)
+	}
+
+	return stream
+}
+
+func send(client proto.SOAChatClient, username string, channel proto.Channel, text string) error {
+	_, err := client.SendMessage(as(username), &proto.SendMessageIn{
+		SessionId: sessionID,
+		Text:      text,
+		Channel:   channel,
+	})
+
+	return err
+}
+
+func recv(t *testing.T, stream proto.SOAChat_SubscribeClient) (*proto.Message, error) {
+	t.Helper()
+
+	type result struct {
+		msg *proto.Message
+		err error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		msg, err := stream.Recv()
+		done <- result{msg, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.msg, r.err
+	case <-time.After(waitTimeout):
+		t.Fatalf("no message in %s", waitTimeout)
+	}
+
+	return nil, nil
+}
+
+func expectCode(t *testing.T, err error, code codes.Code) {
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Fatalf("got %v, want %s", err, code)
+	}
+}
+
+func TestPublicChat(t *testing.T) {
+	client, _ := start(t, map[string]*mafia.Membership{
+		"alice": {Alive: true, Role: mafia.Role_CIVILIAN},
+		"bob":   {Alive: true, Role: mafia.Role_MAFIA},
+	})
+
+	stream := subscribe(t, client, "bob", proto.Channel_CHANNEL_PUBLIC, 0)
+
+	if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, "hi"); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := recv(t, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Username != "alice" || msg.Text != "hi" || msg.Channel != proto.Channel_CHANNEL_PUBLIC || msg.Id == 0 {
+		t.Fatalf("unexpected message: %v", msg)
+	}
+
+	stranger, err := client.Subscribe(as("carol"), &proto.SubscribeIn{SessionId: sessionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = recv(t, stranger)
+	expectCode(t, err, codes.PermissionDenied)
+
+	expectCode(t, send(client, "carol", proto.Channel_CHANNEL_PUBLIC, "hi"), codes.PermissionDenied)
+}
+
+func TestNightChannels(t *testing.T) {
+	client, game := start(t, map[string]*mafia.Membership{
+		"alice": {Alive: true, Role: mafia.Role_CIVILIAN, Night: true},
+		"bob":   {Alive: true, Role: mafia.Role_MAFIA, Night: true},
+		"carol": {Alive: true, Role: mafia.Role_MAFIA, Night: true},
+	})
+
+	expectCode(t, send(client, "alice", proto.Channel_CHANNEL_PUBLIC, "hi"), codes.FailedPrecondition)
+	expectCode(t, send(client, "alice", proto.Channel_CHANNEL_MAFIA, "hi"), codes.PermissionDenied)
+
+	stream := subscribe(t, client, "carol", proto.Channel_CHANNEL_MAFIA, 0)
+
+	if err := send(client, "bob", proto.Channel_CHANNEL_MAFIA, "alice?"); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := recv(t, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Username != "bob" || msg.Text != "alice?" || msg.Channel != proto.Channel_CHANNEL_MAFIA {
+		t.Fatalf("unexpected message: %v", msg)
+	}
+
+	// a killed mafia member is cut off at the first message after their
+	// access has expired
+	game.update("carol", func(m *mafia.Membership) {
+		m.Alive = false
+	})
+	time.Sleep(accessTTL)
+
+	if err := send(client, "bob", proto.Channel_CHANNEL_MAFIA, "sorry"); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = recv(t, stream)
+	expectCode(t, err, codes.PermissionDenied)
+}
+
+func TestAccessIsCached(t *testing.T) {
+	client, game := start(t, map[string]*mafia.Membership{
+		"alice": {Alive: true, Role: mafia.Role_CIVILIAN},
+		"bob":   {Alive: true, Role: mafia.Role_MAFIA},
+	})
+
+	stream := subscribe(t, client, "bob", proto.Channel_CHANNEL_PUBLIC, 0)
+
+	texts := []string{"one", "two", "three", "four"}
+	for _, text := range texts {
+		if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, text); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, text := range texts {
+		msg, err := recv(t, stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if msg.Text != text {
+			t.Fatalf("got %v, want %q", msg, text)
+		}
+	}
+
+	// the messages are delivered on the access checked when subscribing
+	if n := game.lookups("bob"); n != 1 {
+		t.Fatalf("the game server was asked about bob %d times, want once", n)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	client, _ := start(t, map[string]*mafia.Membership{
+		"alice": {Alive: true, Role: mafia.Role_CIVILIAN},
+		"bob":   {Alive: true, Role: mafia.Role_MAFIA},
+	})
+
+	for _, text := range []string{"one", "two", "three"} {
+		if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, text); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the oldest message is dropped, the rest are replayed before the new
+	// ones
+	late := subscribe(t, client, "bob", proto.Channel_CHANNEL_PUBLIC, 0)
+
+	msg, err := recv(t, late)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Text != "two" {
+		t.Fatalf("got %v, want the oldest kept message", msg)
+	}
+
+	// a reconnecting player gets only what they have missed
+	back := subscribe(t, client, "bob", proto.Channel_CHANNEL_PUBLIC, msg.Id)
+
+	if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, "four"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, stream := range []proto.SOAChat_SubscribeClient{late, back} {
+		expectTexts(t, stream, "three", "four")
+	}
+}
+
+func TestCursorAfterEviction(t *testing.T) {
+	messages := history.New(historyLimit, time.Hour)
+	client, _ := startWith(t, map[string]*mafia.Membership{
+		"alice": {Alive: true, Role: mafia.Role_CIVILIAN},
+		"bob":   {Alive: true, Role: mafia.Role_MAFIA},
+	}, messages)
+
+	stream := subscribe(t, client, "bob", proto.Channel_CHANNEL_PUBLIC, 0)
+
+	for _, text := range []string{"a", "b"} {
+		if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, text); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expectTexts(t, stream, "a")
+
+	msg, err := recv(t, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := msg.Id
+
+	// the session goes idle and its history is dropped, the messages sent
+	// afterwards still come after the cursor of the player
+	messages.Evict(time.Now().Add(time.Hour))
+
+	for _, text := range []string{"c", "d"} {
+		if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, text); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	back := subscribe(t, client, "bob", proto.Channel_CHANNEL_PUBLIC, seen)
+
+	if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, "e"); err != nil {
+		t.Fatal(err)
+	}
+
+	expectTexts(t, back, "c", "d", "e")
+}
+
+// expectTexts checks that the next messages of the stream are exactly texts.
+func expectTexts(t *testing.T, stream proto.SOAChat_SubscribeClient, texts ...string) {
+	t.Helper()
+
+	for _, text := range texts {
+		msg, err := recv(t, stream)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if msg.Text != text || msg.Id == 0 || msg.SentAt == 0 {
+			t.Fatalf("got %v, want %q", msg, text)
+		}
+	}
+}
+
+func TestFinishedSessionHistory(t *testing.T) {
+	client, game := start(t, map[string]*mafia.Membership{
+		"alice": {Alive: true, Role: mafia.Role_CIVILIAN},
+		"bob":   {Alive: true, Role: mafia.Role_MAFIA},
+	})
+
+	if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, "one"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, username := range []string{"alice", "bob"} {
+		game.update(username, func(m *mafia.Membership) {
+			m.Finished = true
+		})
+	}
+
+	// the history of a finished game is dropped, later messages are only
+	// relayed
+	stream := subscribe(t, client, "bob", proto.Channel_CHANNEL_PUBLIC, 0)
+
+	if err := send(client, "alice", proto.Channel_CHANNEL_PUBLIC, "gg"); err != nil {
+		t.Fatal(err)
+	}
+
+	msg, err := recv(t, stream)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if msg.Text != "gg" || msg.Id != 0 {
+		t.Fatalf("got %v, want the message sent after the game", msg)
+	}
+}
@@ -0,0 +1,39 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/mcherdakov/soa-mafia/chat/server/internal/generated/mafia"
+)
+
+// member checks with the game server that the caller plays in the session
+// and returns what the game server knows about them. The caller is
+// identified by the game server token, which is passed on as is.
+func (s *SOAChatServer) member(ctx context.Context, sessionID int64) (*mafia.Membership, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	authorization := md.Get("authorization")
+	if len(authorization) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization[0])
+
+	membership, err := s.mafia.GetMembership(ctx, &mafia.MembershipIn{
+		SessionId: sessionID,
+	})
+	if err != nil {
+		// keeps the status of the game server, e.g. an expired token
+		return nil, err
+	}
+
+	if !membership.Member {
+		return nil, status.Error(codes.PermissionDenied, "user is not a member of the session")
+	}
+
+	return membership, nil
+}
@@ -3,9 +3,12 @@ package main
 import (
 	"context"
 	"log"
+	"math/rand"
+	"time"
 
 	"github.com/mcherdakov/soa-mafia/client/internal/cli"
 	"github.com/mcherdakov/soa-mafia/client/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/pkg/clock"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -22,7 +25,9 @@ func run() error {
 	}
 
 	client := proto.NewSOAMafiaClient(conn)
-	return cli.NewCLI(client).Run(context.Background())
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	return cli.NewCLI(client, clock.Real(), rng).Run(context.Background())
 }
 
 func main() {
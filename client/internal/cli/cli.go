@@ -7,11 +7,16 @@ import (
 	"log"
 	"math/rand"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/mcherdakov/soa-mafia/client/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/pkg/clock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 type mode int
@@ -27,6 +32,8 @@ const (
 	stateNew state = iota
 	stateNotConnectedToQueue
 	stateConnectedToQueue
+	stateHostingLobby
+	stateInLobby
 
 	stateWaitingSessionAck
 	stateConnectedToSession
@@ -36,59 +43,113 @@ const (
 type sessionInfo struct {
 	sessionID int64
 	role      proto.Role
+	teammates []string
+	don       *string
+	token     string
+}
+
+// notificationStream is either the queue or lobby stream or the stream
+// opened by a reconnect.
+type notificationStream interface {
+	Recv() (*proto.Notifications, error)
+}
+
+// waitingStream is the stream of a player waiting for a game.
+type waitingStream interface {
+	notificationStream
+	Header() (metadata.MD, error)
 }
 
 type command string
 
 const (
 	commandConnect = "connect"
+	commandQueue   = "queue"
+	commandCreate  = "create"
+	commandJoin    = "join"
+	commandStart   = "start"
+	commandWatch   = "watch"
+)
+
+// watchAll asks for the omniscient spectator mode.
+const watchAll = "all"
+
+const abstainVote = "abstain"
+
+const (
+	reconnectAttempts = 5
+	reconnectDelay    = time.Second * 2
 )
 
 type CLI struct {
 	client proto.SOAMafiaClient
 	reader *bufio.Reader
+	clock  clock.Clock
+	// rng picks the moves of the auto mode.
+	rng *rand.Rand
 
 	userState state
 	stateLock sync.Mutex
 
 	username           string
-	notificationStream proto.SOAMafia_ConnectQueueClient
+	notificationStream notificationStream
 	enterSession       chan sessionInfo
+	lobbyClosed        chan struct{}
+	inviteCode         string
 	day                int64
+	deadline           time.Time
+	// nightTargets are the players who could be picked last night
+	nightTargets []string
+	// streamSet wakes up handleNotifications once the queue or lobby stream
+	// is set
+	streamSet chan struct{}
 }
 
-func NewCLI(client proto.SOAMafiaClient) *CLI {
+func NewCLI(client proto.SOAMafiaClient, clk clock.Clock, rng *rand.Rand) *CLI {
 	return &CLI{
 		client:       client,
 		reader:       bufio.NewReader(os.Stdin),
+		clock:        clk,
+		rng:          rng,
 		userState:    stateNew,
+		streamSet:    make(chan struct{}, 1),
 		enterSession: make(chan sessionInfo),
+		lobbyClosed:  make(chan struct{}),
 	}
 }
 
 func (c *CLI) Run(ctx context.Context) error {
 	go c.handleNotifications()
 
+	// authCtx carries the token of the logged in player
+	authCtx := ctx
+
 	for {
 		switch c.userState {
 		case stateNew:
-			c.handleStateNew()
+			authCtx = c.handleStateNew(ctx)
 		case stateNotConnectedToQueue:
-			c.handleStateNotConnectedToQueue(ctx)
-		case stateConnectedToQueue:
-			c.handleStateConnectedToQueue(ctx)
+			c.handleStateNotConnectedToQueue(authCtx)
+		case stateConnectedToQueue, stateInLobby:
+			c.handleStateConnectedToQueue(authCtx)
+		case stateHostingLobby:
+			c.handleStateHostingLobby(authCtx)
 		}
 	}
 }
 
 func (c *CLI) handleNotifications() {
 	for {
-		if c.notificationStream == nil {
-			time.Sleep(time.Millisecond * 10)
+		c.stateLock.Lock()
+		stream := c.notificationStream
+		c.stateLock.Unlock()
+
+		if stream == nil {
+			<-c.streamSet
 			continue
 		}
 
-		msg, err := c.notificationStream.Recv()
+		msg, err := stream.Recv()
 
 		c.stateLock.Lock()
 
@@ -127,11 +188,42 @@ func (c *CLI) handleNotifications() {
 			}
 
 			c.printCurrentUsers(disconnected.Current)
+		case *proto.Notifications_Lobby:
+			lobby := msg.GetLobby()
+
+			if lobby.Closed {
+				fmt.Println("the host left, lobby is closed")
+
+				c.stateLock.Lock()
+				c.notificationStream = nil
+				c.userState = stateNotConnectedToQueue
+				c.stateLock.Unlock()
+
+				c.lobbyClosed <- struct{}{}
+
+				continue
+			}
+
+			c.stateLock.Lock()
+			c.inviteCode = lobby.InviteCode
+			c.stateLock.Unlock()
+
+			fmt.Printf(
+				"lobby %s, host %s, %d/%d players: %s\n",
+				lobby.InviteCode,
+				lobby.Host,
+				len(lobby.Players),
+				lobby.Capacity,
+				strings.Join(lobby.Players, ", "),
+			)
 		case *proto.Notifications_EnterSession:
 			enterSession := msg.GetEnterSession()
 			c.enterSession <- sessionInfo{
 				sessionID: enterSession.SessionId,
 				role:      enterSession.Role,
+				teammates: enterSession.Teammates,
+				don:       enterSession.Don,
+				token:     enterSession.ReconnectToken,
 			}
 
 			return
@@ -139,36 +231,168 @@ func (c *CLI) handleNotifications() {
 	}
 }
 
-func (c *CLI) handleStateNew() {
+// handleStateNew logs the player in, registering them if the username is new,
+// and returns the context that authenticates further calls.
+func (c *CLI) handleStateNew(ctx context.Context) context.Context {
 	fmt.Print("Enter username: ")
-
 	username := c.input()
+
+	fmt.Print("Enter password: ")
+	password := c.input()
+
+	out, err := c.client.Login(ctx, &proto.LoginIn{
+		Username: username,
+		Password: password,
+	})
+	if status.Code(err) == codes.NotFound {
+		fmt.Printf("registering new user %s\n", username)
+
+		out, err = c.client.Register(ctx, &proto.RegisterIn{
+			Username: username,
+			Password: password,
+		})
+	}
+	if err != nil {
+		fmt.Println(status.Convert(err).Message())
+		return ctx
+	}
+
 	c.username = username
 	c.userState = stateNotConnectedToQueue
+
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+out.Token)
 }
 
 func (c *CLI) handleStateNotConnectedToQueue(ctx context.Context) {
-	stream, err := c.client.ConnectQueue(ctx, &proto.ConnectQueueIn{
-		Username: c.username,
-	})
+	fmt.Printf(
+		"Enter %s to play with anyone, %s to make a private lobby, %s <code> to join one or %s <session id> [%s] to watch a game, default - %s\n",
+		commandQueue,
+		commandCreate,
+		commandJoin,
+		commandWatch,
+		watchAll,
+		commandQueue,
+	)
+
+	var (
+		stream waitingStream
+		err    error
+		next   state
+	)
+
+	switch args := strings.Fields(c.input()); {
+	case len(args) == 0 || args[0] == commandQueue:
+		stream, err = c.client.ConnectQueue(ctx, &proto.ConnectQueueIn{})
+		next = stateConnectedToQueue
+	case args[0] == commandCreate:
+		stream, err = c.client.CreateLobby(ctx, &proto.CreateLobbyIn{
+			Settings: c.lobbySettings(),
+		})
+		next = stateHostingLobby
+	case args[0] == commandJoin && len(args) == 2:
+		stream, err = c.client.JoinLobby(ctx, &proto.JoinLobbyIn{
+			InviteCode: strings.ToUpper(args[1]),
+		})
+		next = stateInLobby
+	case args[0] == commandWatch && (len(args) == 2 || len(args) == 3 && args[2] == watchAll):
+		c.watch(ctx, args[1:])
+		return
+	default:
+		c.invalidCommand()
+		return
+	}
 	if err != nil {
 		fmt.Println(err)
 		return
 	}
 
+	// An admitted player gets a notification right away, which comes with
+	// the header. A rejection is a trailers-only response without one.
+	if header, err := stream.Header(); err != nil || len(header) == 0 {
+		_, err := stream.Recv()
+		if status.Code(err) == codes.AlreadyExists {
+			fmt.Printf("%s, log in as another user\n", status.Convert(err).Message())
+			c.userState = stateNew
+		} else {
+			fmt.Println(status.Convert(err).Message())
+		}
+
+		return
+	}
+
+	c.stateLock.Lock()
 	c.notificationStream = stream
+	c.userState = next
+	c.stateLock.Unlock()
+
+	select {
+	case c.streamSet <- struct{}{}:
+	default:
+	}
+}
+
+// lobbySettings asks the host for the settings of a new lobby. Empty answers
+// keep the server defaults.
+func (c *CLI) lobbySettings() *proto.LobbySettings {
+	settings := &proto.LobbySettings{}
+
+	fmt.Print("Enter maximum number of players, default - server setting: ")
+	if capacity, err := strconv.Atoi(c.input()); err == nil {
+		settings.Capacity = int32(capacity)
+	}
+
+	fmt.Print("Enter ruleset, default - server setting: ")
+	settings.Ruleset = c.input()
+
+	fmt.Print("Enter phase time limit in seconds, default - server setting: ")
+	if seconds, err := strconv.Atoi(c.input()); err == nil {
+		timeout := (time.Duration(seconds) * time.Second).Milliseconds()
+
+		settings.DiscussionTimeoutMs = timeout
+		settings.VoteTimeoutMs = timeout
+		settings.NightTimeoutMs = timeout
+	}
+
+	return settings
+}
+
+func (c *CLI) handleStateHostingLobby(ctx context.Context) {
+	fmt.Printf("Share the invite code with your friends and enter %s once everyone joined\n", commandStart)
+
+	if c.input() != commandStart {
+		return
+	}
 
 	c.stateLock.Lock()
-	c.userState = stateConnectedToQueue
+	inviteCode := c.inviteCode
+	c.stateLock.Unlock()
+
+	_, err := c.client.StartLobby(ctx, &proto.StartLobbyIn{
+		InviteCode: inviteCode,
+	})
+	if err != nil {
+		fmt.Println(status.Convert(err).Message())
+		return
+	}
+
+	c.stateLock.Lock()
+	c.userState = stateInLobby
 	c.stateLock.Unlock()
 }
 
 func (c *CLI) handleStateConnectedToQueue(ctx context.Context) {
-	fmt.Println("waiting for more people to join queue...")
+	if c.userState == stateInLobby {
+		fmt.Println("waiting for the game to start...")
+	} else {
+		fmt.Println("waiting for more people to join queue...")
+	}
 
-	session := <-c.enterSession
-	if err := c.runSession(ctx, session); err != nil {
-		log.Fatalln(err)
+	select {
+	case session := <-c.enterSession:
+		if err := c.runSession(ctx, session); err != nil {
+			log.Fatalln(err)
+		}
+	case <-c.lobbyClosed:
 	}
 }
 
@@ -179,6 +403,13 @@ func (c *CLI) runSession(ctx context.Context, info sessionInfo) error {
 		roleName(info.role),
 	)
 
+	if len(info.teammates) > 0 {
+		fmt.Printf("Your mafia teammates: %s\n", strings.Join(info.teammates, ", "))
+	}
+	if info.don != nil {
+		fmt.Printf("The don of your family is %s\n", *info.don)
+	}
+
 	var m mode
 	switch c.input() {
 	case "manual":
@@ -187,25 +418,54 @@ func (c *CLI) runSession(ctx context.Context, info sessionInfo) error {
 		m = modeAuto
 	}
 
+	// eliminated players watch the rest of the game
+	spectate := func() error {
+		return c.spectate(func() (*proto.Notifications, error) {
+			return c.recv(ctx, info)
+		})
+	}
+
 	for {
 		if err := c.handleDay(ctx, info, m); err != nil {
 			return err
 		}
 
+		if c.userState == stateDead {
+			return spectate()
+		}
+
 		if err := c.handleNight(ctx, info, m); err != nil {
 			return err
 		}
+
+		if c.userState == stateDead {
+			return spectate()
+		}
 	}
 }
 
 func (c *CLI) handleNight(ctx context.Context, info sessionInfo, m mode) error {
-	nt, err := c.awaitNightTime()
+	nt, err := c.awaitNightTime(ctx, info, m)
 	if err != nil {
 		return err
 	}
 
+	c.printVotes(nt.Votes)
+
+	if len(nt.Tied) > 0 {
+		tied := strings.Join(nt.Tied, ", ")
+
+		switch {
+		case nt.RandomPick:
+			fmt.Printf("The vote was tied between %s, %s was picked at random.\n", tied, *nt.VotedOut)
+		case nt.VotedOut == nil:
+			fmt.Printf("The vote was tied between %s, nobody is voted out.\n", tied)
+		}
+	}
+
 	if nt.VotedOut != nil {
 		fmt.Printf("%s was voted out.\n", *nt.VotedOut)
+		c.printRevealedRole(nt.VotedOutRole)
 
 		if *nt.VotedOut == c.username {
 			c.userState = stateDead
@@ -213,25 +473,25 @@ func (c *CLI) handleNight(ctx context.Context, info sessionInfo, m mode) error {
 	}
 
 	fmt.Println("Night falls.")
+	c.setDeadline(nt.Deadline)
+
+	if nt.Phase == proto.Phase_PHASE_PASS {
+		fmt.Printf("Night %d, no action today. Enter any text to proceed\n", c.day)
+		if c.userState == stateDead {
+			return nil
+		}
 
-	if c.day == 1 {
-		fmt.Println("Night 1, no action today. Enter any text to proceed")
 		c.inputAnyting(m)
 
-		_, err = c.client.SendCommand(ctx, &proto.SendCommandIn{
-			SessionId: info.sessionID,
-			Username:  c.username,
-			Command: &proto.Commands{
-				Command: &proto.Commands_PassCommand{
-					PassCommand: &proto.PassCommand{},
-				},
+		return c.sendCommand(ctx, info, &proto.Commands{
+			Command: &proto.Commands_PassCommand{
+				PassCommand: &proto.PassCommand{},
 			},
 		})
-
-		return err
 	}
 
 	availableUsers := strings.Join(nt.Remaining, ", ")
+	c.nightTargets = nt.Remaining
 
 	switch info.role {
 	case proto.Role_CIVILIAN:
@@ -244,19 +504,13 @@ func (c *CLI) handleNight(ctx context.Context, info sessionInfo, m mode) error {
 		fmt.Printf("Pick your victim: %s\n", availableUsers)
 		victim := c.getUsername(nt.Remaining, m)
 
-		_, err := c.client.SendCommand(ctx, &proto.SendCommandIn{
-			SessionId: info.sessionID,
-			Username:  c.username,
-			Command: &proto.Commands{
-				Command: &proto.Commands_KillCommand{
-					KillCommand: &proto.KillCommand{
-						Username: victim,
-					},
+		return c.sendCommand(ctx, info, &proto.Commands{
+			Command: &proto.Commands_KillCommand{
+				KillCommand: &proto.KillCommand{
+					Username: victim,
 				},
 			},
 		})
-
-		return err
 	case proto.Role_DETECITVE:
 		if c.userState == stateDead {
 			return nil
@@ -265,51 +519,51 @@ func (c *CLI) handleNight(ctx context.Context, info sessionInfo, m mode) error {
 		fmt.Printf("Pick your suspect: %s\n", availableUsers)
 		suspect := c.getUsername(nt.Remaining, m)
 
-		_, err := c.client.SendCommand(ctx, &proto.SendCommandIn{
-			SessionId: info.sessionID,
-			Username:  c.username,
-			Command: &proto.Commands{
-				Command: &proto.Commands_CheckCommand{
-					CheckCommand: &proto.CheckCommand{
-						Username: suspect,
-					},
+		return c.sendCommand(ctx, info, &proto.Commands{
+			Command: &proto.Commands_CheckCommand{
+				CheckCommand: &proto.CheckCommand{
+					Username: suspect,
 				},
 			},
 		})
+	case proto.Role_DOCTOR:
+		if c.userState == stateDead {
+			return nil
+		}
 
-		return err
+		for {
+			fmt.Printf("Pick who to heal: %s\n", availableUsers)
+			patient := c.getUsername(nt.Remaining, m)
+
+			rejected, err := c.trySendCommand(ctx, info, &proto.Commands{
+				Command: &proto.Commands_HealCommand{
+					HealCommand: &proto.HealCommand{
+						Username: patient,
+					},
+				},
+			})
+			if err != nil || !rejected {
+				return err
+			}
+		}
 	}
 
 	return nil
 }
 
 func (c *CLI) handleDay(ctx context.Context, info sessionInfo, m mode) error {
-	rs, err := c.awaitRoundStart()
+	rs, err := c.awaitRoundStart(ctx, info, m)
 	if err != nil {
 		return err
 	}
 
 	c.day = rs.Day
-
-	if rs.Day == 1 {
-		fmt.Println("Day 1, no vote today. Enter any text to proceed")
-		c.inputAnyting(m)
-
-		_, err = c.client.SendCommand(ctx, &proto.SendCommandIn{
-			SessionId: info.sessionID,
-			Username:  c.username,
-			Command: &proto.Commands{
-				Command: &proto.Commands_PassCommand{
-					PassCommand: &proto.PassCommand{},
-				},
-			},
-		})
-
-		return err
-	}
+	c.setDeadline(rs.Deadline)
 
 	if rs.KilledUsername != nil {
 		fmt.Printf("%s was killed last night\n", *rs.KilledUsername)
+		c.printRevealedRole(rs.KilledRole)
+
 		if c.username == *rs.KilledUsername {
 			c.userState = stateDead
 		}
@@ -317,9 +571,25 @@ func (c *CLI) handleDay(ctx context.Context, info sessionInfo, m mode) error {
 	if rs.MafiaUsername != nil {
 		fmt.Printf("Detective found out that %s is mafia\n", *rs.MafiaUsername)
 	}
+
+	if rs.Phase == proto.Phase_PHASE_PASS {
+		fmt.Printf("Day %d, no vote today. Enter any text to proceed\n", rs.Day)
+		if c.userState == stateDead {
+			return nil
+		}
+
+		c.inputAnyting(m)
+
+		return c.sendCommand(ctx, info, &proto.Commands{
+			Command: &proto.Commands_PassCommand{
+				PassCommand: &proto.PassCommand{},
+			},
+		})
+	}
 	fmt.Printf(
-		"Day %d. Discuss and enter username to vote, remaining: %s\n",
+		"Day %d. Discuss and enter username to vote or %q to abstain, remaining: %s\n",
 		rs.Day,
+		abstainVote,
 		strings.Join(rs.Remaining, ", "),
 	)
 
@@ -327,22 +597,74 @@ func (c *CLI) handleDay(ctx context.Context, info sessionInfo, m mode) error {
 		return nil
 	}
 
-	vote := c.getUsername(rs.Remaining, m)
-	_, err = c.client.SendCommand(ctx, &proto.SendCommandIn{
-		SessionId: info.sessionID,
-		Username:  c.username,
-		Command: &proto.Commands{
-			Command: &proto.Commands_VoteCommand{
-				VoteCommand: &proto.VoteCommand{
-					Username: vote,
-				},
-			},
+	return c.sendVote(ctx, info, c.getVote(rs.Remaining, m))
+}
+
+func (c *CLI) handleRunoff(ctx context.Context, info sessionInfo, m mode, runoff *proto.RunoffNotification) error {
+	c.printVotes(runoff.Votes)
+	fmt.Printf(
+		"The vote is tied. Runoff between %s, enter username to vote or %q to abstain\n",
+		strings.Join(runoff.Candidates, ", "),
+		abstainVote,
+	)
+	c.setDeadline(runoff.Deadline)
+
+	if c.userState == stateDead {
+		return nil
+	}
+
+	return c.sendVote(ctx, info, c.getVote(runoff.Candidates, m))
+}
+
+func (c *CLI) sendVote(ctx context.Context, info sessionInfo, target *string) error {
+	vote := &proto.VoteCommand{
+		Abstain: target == nil,
+	}
+	if target != nil {
+		vote.Username = *target
+	}
+
+	return c.sendCommand(ctx, info, &proto.Commands{
+		Command: &proto.Commands_VoteCommand{
+			VoteCommand: vote,
 		},
 	})
+}
 
+func (c *CLI) setDeadline(deadline int64) {
+	c.deadline = time.UnixMilli(deadline)
+	fmt.Printf("You have %s to act\n", c.deadline.Sub(c.clock.Now()).Round(time.Second))
+}
+
+// sendCommand sends the command unless the phase deadline has already passed,
+// in which case the server has resolved the action on its own.
+func (c *CLI) sendCommand(ctx context.Context, info sessionInfo, cmd *proto.Commands) error {
+	_, err := c.trySendCommand(ctx, info, cmd)
 	return err
 }
 
+// trySendCommand is like sendCommand but also reports whether the server
+// rejected the command, so that the caller can pick another one.
+func (c *CLI) trySendCommand(ctx context.Context, info sessionInfo, cmd *proto.Commands) (bool, error) {
+	if c.clock.Now().After(c.deadline) {
+		fmt.Println("Time is up, your action was skipped")
+		return false, nil
+	}
+
+	_, err := c.client.SendCommand(ctx, &proto.SendCommandIn{
+		SessionId: info.sessionID,
+		Command:   cmd,
+	})
+
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.PermissionDenied:
+		fmt.Printf("Command rejected: %s\n", status.Convert(err).Message())
+		return true, nil
+	}
+
+	return false, err
+}
+
 func (c *CLI) inputAnyting(m mode) {
 	defer func() {
 		fmt.Println("Waiting for other players")
@@ -361,7 +683,7 @@ func (c *CLI) getUsername(whiteList []string, m mode) string {
 	}()
 
 	if m == modeAuto {
-		name := whiteList[rand.Intn(len(whiteList))]
+		name := whiteList[c.rng.Intn(len(whiteList))]
 		fmt.Printf("Your pick is %s\n", name)
 
 		return name
@@ -384,47 +706,206 @@ func (c *CLI) getUsername(whiteList []string, m mode) string {
 	}
 }
 
-func (c *CLI) awaitRoundStart() (*proto.RoundStartNotification, error) {
-	msg, err := c.notificationStream.Recv()
-	if err != nil {
-		return nil, err
+// getVote reads a vote for one of the candidates. It returns nil if the player
+// abstains.
+func (c *CLI) getVote(candidates []string, m mode) *string {
+	if m == modeAuto {
+		name := c.getUsername(candidates, m)
+		return &name
 	}
 
-	switch msg.Notification.(type) {
-	case *proto.Notifications_RoundStart:
-		rs := msg.GetRoundStart()
-		return rs, nil
-	case *proto.Notifications_ResultNotification:
-		result := msg.GetResultNotification()
-		c.handleResult(result)
+	name := c.getUsername(append(candidates[:len(candidates):len(candidates)], abstainVote), m)
+	if name == abstainVote {
+		return nil
+	}
 
-		return nil, nil
-	default:
-		return nil, fmt.Errorf("unexpected notification")
+	return &name
+}
+
+// recv receives the next session notification. If the connection to the
+// server is lost, it reconnects to the session and skips the replayed
+// greeting.
+func (c *CLI) recv(ctx context.Context, info sessionInfo) (*proto.Notifications, error) {
+	for {
+		msg, err := c.notificationStream.Recv()
+		if err != nil {
+			if status.Code(err) != codes.Unavailable {
+				return nil, err
+			}
+
+			if err := c.reconnect(ctx, info); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		if _, ok := msg.Notification.(*proto.Notifications_EnterSession); ok {
+			continue
+		}
+
+		return msg, nil
 	}
 }
 
-func (c *CLI) awaitNightTime() (*proto.NightTimeNotification, error) {
-	msg, err := c.notificationStream.Recv()
-	if err != nil {
-		return nil, err
+func (c *CLI) reconnect(ctx context.Context, info sessionInfo) error {
+	var err error
+
+	for i := 0; i < reconnectAttempts; i++ {
+		fmt.Println("connection lost, reconnecting...")
+		c.clock.Sleep(reconnectDelay)
+
+		var stream proto.SOAMafia_ReconnectClient
+
+		stream, err = c.client.Reconnect(ctx, &proto.ReconnectIn{
+			SessionId:      info.sessionID,
+			ReconnectToken: info.token,
+		})
+		if err == nil {
+			c.notificationStream = stream
+			return nil
+		}
+	}
+
+	return err
+}
+
+func (c *CLI) awaitRoundStart(ctx context.Context, info sessionInfo, m mode) (*proto.RoundStartNotification, error) {
+	for {
+		msg, err := c.recv(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+
+		switch msg.Notification.(type) {
+		case *proto.Notifications_NightTime:
+			// replayed after a reconnect, the night is already handled
+			continue
+		case *proto.Notifications_RoundStart:
+			rs := msg.GetRoundStart()
+			return rs, nil
+		case *proto.Notifications_MafiaPicks:
+			if err := c.handleMafiaPicks(ctx, info, m, msg.GetMafiaPicks()); err != nil {
+				return nil, err
+			}
+		case *proto.Notifications_InvestigationResult:
+			c.printInvestigationResult(msg.GetInvestigationResult())
+		case *proto.Notifications_Spectate:
+			// sent right before the round start that tells about our death
+			c.printSpectate(msg.GetSpectate())
+		case *proto.Notifications_ResultNotification:
+			result := msg.GetResultNotification()
+			c.handleResult(result)
+
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("unexpected notification")
+		}
 	}
+}
 
-	switch msg.Notification.(type) {
-	case *proto.Notifications_NightTime:
-		nt := msg.GetNightTime()
-		return nt, nil
-	case *proto.Notifications_ResultNotification:
-		result := msg.GetResultNotification()
-		c.handleResult(result)
+// handleMafiaPicks shows the picks of the mafia team and lets the player
+// change their own pick while the team disagrees.
+func (c *CLI) handleMafiaPicks(ctx context.Context, info sessionInfo, m mode, picks *proto.MafiaPicksNotification) error {
+	fmt.Println("Mafia picks:")
+	c.printVotes(picks.Picks)
 
-		return nil, nil
-	default:
-		return nil, fmt.Errorf("unexpected notification")
+	if picks.Settled || c.userState == stateDead || c.clock.Now().After(c.deadline) {
+		return nil
+	}
+
+	var own *string
+	for _, pick := range picks.Picks {
+		if pick.Voter == c.username {
+			own = pick.Username
+		}
+	}
+
+	if own == nil {
+		// our own pick has not reached the server yet
+		return nil
+	}
+
+	conflict := false
+	for _, pick := range picks.Picks {
+		if *pick.Username != *own {
+			conflict = true
+		}
+	}
+
+	if !conflict {
+		return nil
+	}
+
+	var victim string
+
+	if m == modeAuto {
+		// follow the first pick so that the family comes to an agreement
+		victim = *picks.Picks[0].Username
+		fmt.Printf("Your pick is %s\n", victim)
+	} else {
+		fmt.Printf(
+			"Your family disagrees. Pick your victim again: %s\n",
+			strings.Join(c.nightTargets, ", "),
+		)
+		victim = c.getUsername(c.nightTargets, m)
+	}
+
+	if victim == *own {
+		return nil
+	}
+
+	return c.sendCommand(ctx, info, &proto.Commands{
+		Command: &proto.Commands_KillCommand{
+			KillCommand: &proto.KillCommand{
+				Username: victim,
+			},
+		},
+	})
+}
+
+func (c *CLI) awaitNightTime(ctx context.Context, info sessionInfo, m mode) (*proto.NightTimeNotification, error) {
+	for {
+		msg, err := c.recv(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+
+		switch msg.Notification.(type) {
+		case *proto.Notifications_RoundStart:
+			if msg.GetRoundStart().Day == c.day {
+				// replayed after a reconnect, the day is already handled
+				continue
+			}
+
+			return nil, fmt.Errorf("unexpected notification")
+		case *proto.Notifications_NightTime:
+			nt := msg.GetNightTime()
+			return nt, nil
+		case *proto.Notifications_Runoff:
+			if err := c.handleRunoff(ctx, info, m, msg.GetRunoff()); err != nil {
+				return nil, err
+			}
+		case *proto.Notifications_Spectate:
+			// sent right before the night that tells we were voted out
+			c.printSpectate(msg.GetSpectate())
+		case *proto.Notifications_ResultNotification:
+			result := msg.GetResultNotification()
+			c.handleResult(result)
+
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("unexpected notification")
+		}
 	}
 }
 
 func (c *CLI) handleResult(result *proto.ResultNotification) {
+	if result.Aborted {
+		fmt.Printf("game aborted: %s\n", result.Reason)
+		os.Exit(1)
+	}
+
 	fmt.Printf("game finished, winner role is %s\n", roleName(result.Winner))
 	os.Exit(0)
 }
@@ -442,6 +923,32 @@ func (c *CLI) invalidCommand() {
 	fmt.Println("invalid command")
 }
 
+func (c *CLI) printInvestigationResult(result *proto.InvestigationResultNotification) {
+	if result.IsMafia {
+		fmt.Printf("Your investigation shows that %s is mafia\n", result.Username)
+		return
+	}
+
+	fmt.Printf("Your investigation shows that %s is not mafia\n", result.Username)
+}
+
+func (c *CLI) printRevealedRole(role *proto.Role) {
+	if role != nil {
+		fmt.Printf("Their role was %s\n", roleName(*role))
+	}
+}
+
+func (c *CLI) printVotes(votes []*proto.Vote) {
+	for _, vote := range votes {
+		if vote.Username == nil {
+			fmt.Printf("%s abstained\n", vote.Voter)
+			continue
+		}
+
+		fmt.Printf("%s voted for %s\n", vote.Voter, *vote.Username)
+	}
+}
+
 func (c *CLI) printCurrentUsers(users []string) {
 	if len(users) == 0 {
 		fmt.Println("Currently there are no users in the queue")
@@ -459,6 +966,8 @@ func roleName(r proto.Role) string {
 		return "detective"
 	case proto.Role_MAFIA:
 		return "mafia"
+	case proto.Role_DOCTOR:
+		return "doctor"
 	default:
 		return "unknown role"
 	}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mcherdakov/soa-mafia/client/internal/generated/proto"
+	"google.golang.org/grpc/status"
+)
+
+// watch follows a game as a spectator, args are the session ID and optionally
+// watchAll for omniscient mode.
+func (c *CLI) watch(ctx context.Context, args []string) {
+	sessionID, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		c.invalidCommand()
+		return
+	}
+
+	stream, err := c.client.WatchSession(ctx, &proto.WatchSessionIn{
+		SessionId:  sessionID,
+		Omniscient: len(args) == 2 && args[1] == watchAll,
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	err = c.spectate(stream.Recv)
+	if err == io.EOF {
+		fmt.Println("the game is over")
+		return
+	}
+
+	fmt.Println(status.Convert(err).Message())
+}
+
+// spectate prints the game to a spectator or an eliminated player until it
+// ends.
+func (c *CLI) spectate(next func() (*proto.Notifications, error)) error {
+	for {
+		msg, err := next()
+		if err != nil {
+			return err
+		}
+
+		switch msg.Notification.(type) {
+		case *proto.Notifications_Spectate:
+			c.printSpectate(msg.GetSpectate())
+		case *proto.Notifications_NightAction:
+			c.printNightAction(msg.GetNightAction())
+		case *proto.Notifications_RoundStart:
+			rs := msg.GetRoundStart()
+
+			if rs.KilledUsername != nil {
+				fmt.Printf("%s was killed last night\n", *rs.KilledUsername)
+				c.printRevealedRole(rs.KilledRole)
+			}
+			if rs.MafiaUsername != nil {
+				fmt.Printf("Detective found out that %s is mafia\n", *rs.MafiaUsername)
+			}
+
+			fmt.Printf("Day %d, remaining: %s\n", rs.Day, strings.Join(rs.Remaining, ", "))
+		case *proto.Notifications_Runoff:
+			runoff := msg.GetRunoff()
+
+			c.printVotes(runoff.Votes)
+			fmt.Printf("Runoff between %s\n", strings.Join(runoff.Candidates, ", "))
+		case *proto.Notifications_NightTime:
+			nt := msg.GetNightTime()
+
+			c.printVotes(nt.Votes)
+			if nt.VotedOut != nil {
+				fmt.Printf("%s was voted out.\n", *nt.VotedOut)
+				c.printRevealedRole(nt.VotedOutRole)
+			}
+
+			fmt.Println("Night falls.")
+		case *proto.Notifications_ResultNotification:
+			c.handleResult(msg.GetResultNotification())
+		}
+	}
+}
+
+func (c *CLI) printSpectate(spectate *proto.SpectateNotification) {
+	fmt.Printf("You are watching session %d\n", spectate.SessionId)
+
+	for _, player := range spectate.Roles {
+		fmt.Printf("%s is %s\n", player.Username, roleName(player.Role))
+	}
+}
+
+func (c *CLI) printNightAction(action *proto.NightActionNotification) {
+	switch action.Role {
+	case proto.Role_MAFIA:
+		fmt.Printf("%s (mafia) picks %s\n", action.Username, action.Target)
+	case proto.Role_DETECITVE:
+		fmt.Printf("%s (detective) checks %s\n", action.Username, action.Target)
+	case proto.Role_DOCTOR:
+		fmt.Printf("%s (doctor) heals %s\n", action.Username, action.Target)
+	}
+}
@@ -22,9 +22,27 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type SOAMafiaClient interface {
+	// Register and Login are the only calls that do not need the token in the
+	// "authorization: Bearer <token>" metadata.
+	Register(ctx context.Context, in *RegisterIn, opts ...grpc.CallOption) (*LoginOut, error)
+	Login(ctx context.Context, in *LoginIn, opts ...grpc.CallOption) (*LoginOut, error)
 	ConnectQueue(ctx context.Context, in *ConnectQueueIn, opts ...grpc.CallOption) (SOAMafia_ConnectQueueClient, error)
 	DisconnectQueue(ctx context.Context, in *DisconnectQueueIn, opts ...grpc.CallOption) (*DisconnectQueueOut, error)
 	SendCommand(ctx context.Context, in *SendCommandIn, opts ...grpc.CallOption) (*SendCommandOut, error)
+	// resumes notifications of an in-progress session after a lost connection
+	Reconnect(ctx context.Context, in *ReconnectIn, opts ...grpc.CallOption) (SOAMafia_ReconnectClient, error)
+	// private lobbies, the first notification of both streams carries the invite code
+	CreateLobby(ctx context.Context, in *CreateLobbyIn, opts ...grpc.CallOption) (SOAMafia_CreateLobbyClient, error)
+	JoinLobby(ctx context.Context, in *JoinLobbyIn, opts ...grpc.CallOption) (SOAMafia_JoinLobbyClient, error)
+	StartLobby(ctx context.Context, in *StartLobbyIn, opts ...grpc.CallOption) (*StartLobbyOut, error)
+	// finished games, most recent first
+	ListGames(ctx context.Context, in *ListGamesIn, opts ...grpc.CallOption) (*ListGamesOut, error)
+	GetGame(ctx context.Context, in *GetGameIn, opts ...grpc.CallOption) (*GameRecord, error)
+	// public notifications of a session, the first one is SpectateNotification
+	WatchSession(ctx context.Context, in *WatchSessionIn, opts ...grpc.CallOption) (SOAMafia_WatchSessionClient, error)
+	// tells other services, e.g. the chat, who the caller is and whether they
+	// play in the session
+	GetMembership(ctx context.Context, in *MembershipIn, opts ...grpc.CallOption) (*Membership, error)
 }
 
 type sOAMafiaClient struct {
@@ -35,6 +53,24 @@ func NewSOAMafiaClient(cc grpc.ClientConnInterface) SOAMafiaClient {
 	return &sOAMafiaClient{cc}
 }
 
+func (c *sOAMafiaClient) Register(ctx context.Context, in *RegisterIn, opts ...grpc.CallOption) (*LoginOut, error) {
+	out := new(LoginOut)
+	err := c.cc.Invoke(ctx, "/SOAMafia/Register", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sOAMafiaClient) Login(ctx context.Context, in *LoginIn, opts ...grpc.CallOption) (*LoginOut, error) {
+	out := new(LoginOut)
+	err := c.cc.Invoke(ctx, "/SOAMafia/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *sOAMafiaClient) ConnectQueue(ctx context.Context, in *ConnectQueueIn, opts ...grpc.CallOption) (SOAMafia_ConnectQueueClient, error) {
 	stream, err := c.cc.NewStream(ctx, &SOAMafia_ServiceDesc.Streams[0], "/SOAMafia/ConnectQueue", opts...)
 	if err != nil {
@@ -85,13 +121,195 @@ func (c *sOAMafiaClient) SendCommand(ctx context.Context, in *SendCommandIn, opt
 	return out, nil
 }
 
+func (c *sOAMafiaClient) Reconnect(ctx context.Context, in *ReconnectIn, opts ...grpc.CallOption) (SOAMafia_ReconnectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SOAMafia_ServiceDesc.Streams[1], "/SOAMafia/Reconnect", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sOAMafiaReconnectClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SOAMafia_ReconnectClient interface {
+	Recv() (*Notifications, error)
+	grpc.ClientStream
+}
+
+type sOAMafiaReconnectClient struct {
+	grpc.ClientStream
+}
+
+func (x *sOAMafiaReconnectClient) Recv() (*Notifications, error) {
+	m := new(Notifications)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sOAMafiaClient) CreateLobby(ctx context.Context, in *CreateLobbyIn, opts ...grpc.CallOption) (SOAMafia_CreateLobbyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SOAMafia_ServiceDesc.Streams[2], "/SOAMafia/CreateLobby", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sOAMafiaCreateLobbyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SOAMafia_CreateLobbyClient interface {
+	Recv() (*Notifications, error)
+	grpc.ClientStream
+}
+
+type sOAMafiaCreateLobbyClient struct {
+	grpc.ClientStream
+}
+
+func (x *sOAMafiaCreateLobbyClient) Recv() (*Notifications, error) {
+	m := new(Notifications)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sOAMafiaClient) JoinLobby(ctx context.Context, in *JoinLobbyIn, opts ...grpc.CallOption) (SOAMafia_JoinLobbyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SOAMafia_ServiceDesc.Streams[3], "/SOAMafia/JoinLobby", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sOAMafiaJoinLobbyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SOAMafia_JoinLobbyClient interface {
+	Recv() (*Notifications, error)
+	grpc.ClientStream
+}
+
+type sOAMafiaJoinLobbyClient struct {
+	grpc.ClientStream
+}
+
+func (x *sOAMafiaJoinLobbyClient) Recv() (*Notifications, error) {
+	m := new(Notifications)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sOAMafiaClient) StartLobby(ctx context.Context, in *StartLobbyIn, opts ...grpc.CallOption) (*StartLobbyOut, error) {
+	out := new(StartLobbyOut)
+	err := c.cc.Invoke(ctx, "/SOAMafia/StartLobby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sOAMafiaClient) ListGames(ctx context.Context, in *ListGamesIn, opts ...grpc.CallOption) (*ListGamesOut, error) {
+	out := new(ListGamesOut)
+	err := c.cc.Invoke(ctx, "/SOAMafia/ListGames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sOAMafiaClient) GetGame(ctx context.Context, in *GetGameIn, opts ...grpc.CallOption) (*GameRecord, error) {
+	out := new(GameRecord)
+	err := c.cc.Invoke(ctx, "/SOAMafia/GetGame", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sOAMafiaClient) WatchSession(ctx context.Context, in *WatchSessionIn, opts ...grpc.CallOption) (SOAMafia_WatchSessionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SOAMafia_ServiceDesc.Streams[4], "/SOAMafia/WatchSession", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &sOAMafiaWatchSessionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SOAMafia_WatchSessionClient interface {
+	Recv() (*Notifications, error)
+	grpc.ClientStream
+}
+
+type sOAMafiaWatchSessionClient struct {
+	grpc.ClientStream
+}
+
+func (x *sOAMafiaWatchSessionClient) Recv() (*Notifications, error) {
+	m := new(Notifications)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *sOAMafiaClient) GetMembership(ctx context.Context, in *MembershipIn, opts ...grpc.CallOption) (*Membership, error) {
+	out := new(Membership)
+	err := c.cc.Invoke(ctx, "/SOAMafia/GetMembership", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SOAMafiaServer is the server API for SOAMafia service.
 // All implementations must embed UnimplementedSOAMafiaServer
 // for forward compatibility
 type SOAMafiaServer interface {
+	// Register and Login are the only calls that do not need the token in the
+	// "authorization: Bearer <token>" metadata.
+	Register(context.Context, *RegisterIn) (*LoginOut, error)
+	Login(context.Context, *LoginIn) (*LoginOut, error)
 	ConnectQueue(*ConnectQueueIn, SOAMafia_ConnectQueueServer) error
 	DisconnectQueue(context.Context, *DisconnectQueueIn) (*DisconnectQueueOut, error)
 	SendCommand(context.Context, *SendCommandIn) (*SendCommandOut, error)
+	// resumes notifications of an in-progress session after a lost connection
+	Reconnect(*ReconnectIn, SOAMafia_ReconnectServer) error
+	// private lobbies, the first notification of both streams carries the invite code
+	CreateLobby(*CreateLobbyIn, SOAMafia_CreateLobbyServer) error
+	JoinLobby(*JoinLobbyIn, SOAMafia_JoinLobbyServer) error
+	StartLobby(context.Context, *StartLobbyIn) (*StartLobbyOut, error)
+	// finished games, most recent first
+	ListGames(context.Context, *ListGamesIn) (*ListGamesOut, error)
+	GetGame(context.Context, *GetGameIn) (*GameRecord, error)
+	// public notifications of a session, the first one is SpectateNotification
+	WatchSession(*WatchSessionIn, SOAMafia_WatchSessionServer) error
+	// tells other services, e.g. the chat, who the caller is and whether they
+	// play in the session
+	GetMembership(context.Context, *MembershipIn) (*Membership, error)
 	mustEmbedUnimplementedSOAMafiaServer()
 }
 
@@ -99,6 +317,12 @@ type SOAMafiaServer interface {
 type UnimplementedSOAMafiaServer struct {
 }
 
+func (UnimplementedSOAMafiaServer) Register(context.Context, *RegisterIn) (*LoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedSOAMafiaServer) Login(context.Context, *LoginIn) (*LoginOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
 func (UnimplementedSOAMafiaServer) ConnectQueue(*ConnectQueueIn, SOAMafia_ConnectQueueServer) error {
 	return status.Errorf(codes.Unimplemented, "method ConnectQueue not implemented")
 }
@@ -108,6 +332,30 @@ func (UnimplementedSOAMafiaServer) DisconnectQueue(context.Context, *DisconnectQ
 func (UnimplementedSOAMafiaServer) SendCommand(context.Context, *SendCommandIn) (*SendCommandOut, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SendCommand not implemented")
 }
+func (UnimplementedSOAMafiaServer) Reconnect(*ReconnectIn, SOAMafia_ReconnectServer) error {
+	return status.Errorf(codes.Unimplemented, "method Reconnect not implemented")
+}
+func (UnimplementedSOAMafiaServer) CreateLobby(*CreateLobbyIn, SOAMafia_CreateLobbyServer) error {
+	return status.Errorf(codes.Unimplemented, "method CreateLobby not implemented")
+}
+func (UnimplementedSOAMafiaServer) JoinLobby(*JoinLobbyIn, SOAMafia_JoinLobbyServer) error {
+	return status.Errorf(codes.Unimplemented, "method JoinLobby not implemented")
+}
+func (UnimplementedSOAMafiaServer) StartLobby(context.Context, *StartLobbyIn) (*StartLobbyOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartLobby not implemented")
+}
+func (UnimplementedSOAMafiaServer) ListGames(context.Context, *ListGamesIn) (*ListGamesOut, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGames not implemented")
+}
+func (UnimplementedSOAMafiaServer) GetGame(context.Context, *GetGameIn) (*GameRecord, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGame not implemented")
+}
+func (UnimplementedSOAMafiaServer) WatchSession(*WatchSessionIn, SOAMafia_WatchSessionServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchSession not implemented")
+}
+func (UnimplementedSOAMafiaServer) GetMembership(context.Context, *MembershipIn) (*Membership, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMembership not implemented")
+}
 func (UnimplementedSOAMafiaServer) mustEmbedUnimplementedSOAMafiaServer() {}
 
 // UnsafeSOAMafiaServer may be embedded to opt out of forward compatibility for this service.
@@ -121,6 +369,42 @@ func RegisterSOAMafiaServer(s grpc.ServiceRegistrar, srv SOAMafiaServer) {
 	s.RegisterService(&SOAMafia_ServiceDesc, srv)
 }
 
+func _SOAMafia_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SOAMafiaServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SOAMafia/Register",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SOAMafiaServer).Register(ctx, req.(*RegisterIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SOAMafia_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SOAMafiaServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SOAMafia/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SOAMafiaServer).Login(ctx, req.(*LoginIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SOAMafia_ConnectQueue_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ConnectQueueIn)
 	if err := stream.RecvMsg(m); err != nil {
@@ -178,6 +462,162 @@ func _SOAMafia_SendCommand_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SOAMafia_Reconnect_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReconnectIn)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SOAMafiaServer).Reconnect(m, &sOAMafiaReconnectServer{stream})
+}
+
+type SOAMafia_ReconnectServer interface {
+	Send(*Notifications) error
+	grpc.ServerStream
+}
+
+type sOAMafiaReconnectServer struct {
+	grpc.ServerStream
+}
+
+func (x *sOAMafiaReconnectServer) Send(m *Notifications) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SOAMafia_CreateLobby_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(CreateLobbyIn)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SOAMafiaServer).CreateLobby(m, &sOAMafiaCreateLobbyServer{stream})
+}
+
+type SOAMafia_CreateLobbyServer interface {
+	Send(*Notifications) error
+	grpc.ServerStream
+}
+
+type sOAMafiaCreateLobbyServer struct {
+	grpc.ServerStream
+}
+
+func (x *sOAMafiaCreateLobbyServer) Send(m *Notifications) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SOAMafia_JoinLobby_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JoinLobbyIn)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SOAMafiaServer).JoinLobby(m, &sOAMafiaJoinLobbyServer{stream})
+}
+
+type SOAMafia_JoinLobbyServer interface {
+	Send(*Notifications) error
+	grpc.ServerStream
+}
+
+type sOAMafiaJoinLobbyServer struct {
+	grpc.ServerStream
+}
+
+func (x *sOAMafiaJoinLobbyServer) Send(m *Notifications) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SOAMafia_StartLobby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartLobbyIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SOAMafiaServer).StartLobby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SOAMafia/StartLobby",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SOAMafiaServer).StartLobby(ctx, req.(*StartLobbyIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SOAMafia_ListGames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGamesIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SOAMafiaServer).ListGames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SOAMafia/ListGames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SOAMafiaServer).ListGames(ctx, req.(*ListGamesIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SOAMafia_GetGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SOAMafiaServer).GetGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SOAMafia/GetGame",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SOAMafiaServer).GetGame(ctx, req.(*GetGameIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SOAMafia_WatchSession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchSessionIn)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SOAMafiaServer).WatchSession(m, &sOAMafiaWatchSessionServer{stream})
+}
+
+type SOAMafia_WatchSessionServer interface {
+	Send(*Notifications) error
+	grpc.ServerStream
+}
+
+type sOAMafiaWatchSessionServer struct {
+	grpc.ServerStream
+}
+
+func (x *sOAMafiaWatchSessionServer) Send(m *Notifications) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SOAMafia_GetMembership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MembershipIn)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SOAMafiaServer).GetMembership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/SOAMafia/GetMembership",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SOAMafiaServer).GetMembership(ctx, req.(*MembershipIn))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SOAMafia_ServiceDesc is the grpc.ServiceDesc for SOAMafia service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -185,6 +625,14 @@ var SOAMafia_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "SOAMafia",
 	HandlerType: (*SOAMafiaServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _SOAMafia_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _SOAMafia_Login_Handler,
+		},
 		{
 			MethodName: "DisconnectQueue",
 			Handler:    _SOAMafia_DisconnectQueue_Handler,
@@ -193,6 +641,22 @@ var SOAMafia_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SendCommand",
 			Handler:    _SOAMafia_SendCommand_Handler,
 		},
+		{
+			MethodName: "StartLobby",
+			Handler:    _SOAMafia_StartLobby_Handler,
+		},
+		{
+			MethodName: "ListGames",
+			Handler:    _SOAMafia_ListGames_Handler,
+		},
+		{
+			MethodName: "GetGame",
+			Handler:    _SOAMafia_GetGame_Handler,
+		},
+		{
+			MethodName: "GetMembership",
+			Handler:    _SOAMafia_GetMembership_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -200,6 +664,26 @@ var SOAMafia_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SOAMafia_ConnectQueue_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Reconnect",
+			Handler:       _SOAMafia_Reconnect_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "CreateLobby",
+			Handler:       _SOAMafia_CreateLobby_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "JoinLobby",
+			Handler:       _SOAMafia_JoinLobby_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchSession",
+			Handler:       _SOAMafia_WatchSession_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "service.proto",
 }
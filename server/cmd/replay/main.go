@@ -0,0 +1,75 @@
+// Command replay re-runs a session event log through the session logic and
+// checks that it produces the same events, e.g.
+//
+//	go run ./server/cmd/replay events/20240101-120000-session-1.jsonl
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/eventlog"
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+)
+
+func run() error {
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		return fmt.Errorf("usage: replay <event log>")
+	}
+
+	logged, err := eventlog.Read(flag.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	replayed, err := session.Replay(logged)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range replayed {
+		fmt.Println(describe(e))
+	}
+
+	if err := session.Verify(logged, replayed); err != nil {
+		return fmt.Errorf("replay does not match the log: %w", err)
+	}
+
+	fmt.Printf("replay matches the log, %d events\n", len(logged))
+
+	return nil
+}
+
+func describe(e session.Event) string {
+	switch e.Type {
+	case session.EventSessionCreated:
+		return fmt.Sprintf("session %d created, players %v, seed %d", e.SessionID, e.Players, e.Seed)
+	case session.EventRolesAssigned:
+		return fmt.Sprintf("roles assigned: %v", e.Roles)
+	case session.EventPhaseChanged:
+		return fmt.Sprintf("day %d: %s phase %v", e.Day, e.Phase, e.Candidates)
+	case session.EventCommandReceived:
+		return fmt.Sprintf("day %d: %s sent %+v", e.Day, e.Username, *e.Command)
+	case session.EventPhaseClosed:
+		return fmt.Sprintf("day %d: phase closed", e.Day)
+	case session.EventPlayerEliminated:
+		return fmt.Sprintf("day %d: %s eliminated by %s", e.Day, e.Username, e.Reason)
+	case session.EventGameEnded:
+		if e.Winner == nil {
+			return fmt.Sprintf("game aborted: %s", e.Reason)
+		}
+
+		return fmt.Sprintf("game won by %s", e.Winner)
+	default:
+		return string(e.Type)
+	}
+}
+
+func main() {
+	if err := run(); err != nil {
+		log.Fatalln(err)
+	}
+}
@@ -1,33 +1,138 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"math/rand"
 	"net"
+	"strings"
+	"time"
 
+	"github.com/mcherdakov/soa-mafia/pkg/clock"
+	"github.com/mcherdakov/soa-mafia/server/internal/auth"
+	"github.com/mcherdakov/soa-mafia/server/internal/eventlog"
 	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/history"
+	"github.com/mcherdakov/soa-mafia/server/internal/lobby"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
 	"github.com/mcherdakov/soa-mafia/server/internal/queue"
+	"github.com/mcherdakov/soa-mafia/server/internal/rating"
 	"github.com/mcherdakov/soa-mafia/server/internal/rpc"
+	"github.com/mcherdakov/soa-mafia/server/internal/rules"
 	"github.com/mcherdakov/soa-mafia/server/internal/session"
 	"google.golang.org/grpc"
 )
 
 func run() error {
+	cfg := session.DefaultConfig()
+	flag.IntVar(&cfg.Capacity, "capacity", cfg.Capacity, "number of players in a session")
+	flag.DurationVar(&cfg.DiscussionTimeout, "discussion-timeout", cfg.DiscussionTimeout, "time limit for day 1 and night 1")
+	flag.DurationVar(&cfg.VoteTimeout, "vote-timeout", cfg.VoteTimeout, "time limit for the day vote")
+	flag.DurationVar(&cfg.NightTimeout, "night-timeout", cfg.NightTimeout, "time limit for night actions")
+	flag.Var(&cfg.TieRule, "tie-rule", "how to resolve a tied vote: none, runoff or random")
+	flag.BoolVar(&cfg.ForbidRepeatHeal, "forbid-repeat-heal", cfg.ForbidRepeatHeal, "forbid the doctor to heal the same player two nights in a row")
+	flag.Var(&cfg.KillRule, "kill-rule", "how the mafia agree on the night kill: majority, unanimous or don")
+	flag.BoolVar(&cfg.PublishDetectiveFindings, "publish-detective-findings", cfg.PublishDetectiveFindings, "announce to everyone when the detective finds mafia")
+	flag.DurationVar(&cfg.ReconnectGrace, "reconnect-grace", cfg.ReconnectGrace, "how long to wait for a disconnected player before skipping their actions")
+	ruleset := flag.String("ruleset", cfg.Ruleset.Name(), fmt.Sprintf("game variant, one of %v", rules.Names()))
+	retention := flag.Duration("session-retention", time.Minute*10, "how long finished sessions are kept")
+	usersPath := flag.String("users", "users.json", "file with registered users")
+	tokenTTL := flag.Duration("token-ttl", time.Hour*24, "how long a login token is valid")
+	matchmaker := flag.String("matchmaker", "fifo", "how the public queue groups players: fifo or rating")
+	ratingsPath := flag.String("ratings", "ratings.json", "file with player ratings")
+	ratingWindow := flag.Float64("rating-window", 100, "largest rating spread of a group of players who have just joined")
+	ratingGrowth := flag.Float64("rating-window-growth", 10, "how much the rating window widens per second of waiting")
+	historyPath := flag.String("history", "history.db", "file with finished games")
+	eventsPath := flag.String("event-log", "events", "directory with the event logs of sessions")
+	spectators := flag.String("spectators", "", "comma separated users who may watch any session in omniscient mode")
+	flag.Parse()
+
+	var err error
+	if cfg.Ruleset, err = rules.ByName(*ruleset); err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	users, err := auth.NewFileStore(*usersPath)
+	if err != nil {
+		return err
+	}
+
+	ratings, err := rating.NewElo(*ratingsPath)
+	if err != nil {
+		return err
+	}
+
+	mm, err := queue.MatchmakerByName(*matchmaker, ratings, *ratingWindow, *ratingGrowth)
+	if err != nil {
+		return err
+	}
+
+	games, err := history.Open(*historyPath)
+	if err != nil {
+		return err
+	}
+	defer games.Close()
+
+	events, err := eventlog.NewDir(*eventsPath)
+	if err != nil {
+		return err
+	}
+
 	listener, err := net.Listen("tcp", ":9000")
 	if err != nil {
 		return err
 	}
 
-	sessionManager := session.NewSessionManager()
+	registry := models.NewRegistry()
+	sessionManager := session.NewSessionManager(*retention, registry, clock.Real(), rand.New(rand.NewSource(time.Now().UnixNano())))
+	sessionManager.OnFinished(func(result session.Result) {
+		if err := ratings.Update(result.Roles, result.Winner); err != nil {
+			log.Printf("session %d: can not update ratings: %v\n", result.SessionID, err)
+		}
+	})
+	sessionManager.OnFinished(func(result session.Result) {
+		gameID, err := games.Save(result)
+		if err != nil {
+			log.Printf("session %d: can not save game: %v\n", result.SessionID, err)
+			return
+		}
+
+		log.Printf("session %d saved as game %d\n", result.SessionID, gameID)
+	})
+
+	sessionManager.OnEvent(func(sessionID int64, e session.Event) {
+		if err := events.Append(sessionID, e); err != nil {
+			log.Printf("session %d: can not log event: %v\n", sessionID, err)
+		}
+	})
 
 	go sessionManager.Run()
 
-	s := grpc.NewServer()
+	q := queue.NewQueue(sessionManager.Chan(), cfg, mm, registry)
+	go q.Run()
+
+	tokens := auth.NewTokens(*tokenTTL)
+	interceptor := auth.NewInterceptor(tokens, rpc.PublicMethods...)
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Unary()),
+		grpc.StreamInterceptor(interceptor.Stream()),
+	)
 	proto.RegisterSOAMafiaServer(
 		s,
 		rpc.NewSOAMafiaServer(
-			queue.NewQueue(sessionManager.Chan()),
+			q,
+			lobby.NewLobbies(sessionManager.Chan(), cfg, registry),
 			sessionManager,
+			games,
+			users,
+			tokens,
+			strings.FieldsFunc(*spectators, func(r rune) bool { return r == ',' }),
 		),
 	)
 
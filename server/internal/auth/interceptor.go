@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	metadataKey  = "authorization"
+	bearerPrefix = "Bearer "
+)
+
+type usernameKey struct{}
+
+// Interceptor authenticates every call except the ones listed as public and
+// puts the username of the caller into the request context.
+type Interceptor struct {
+	tokens *Tokens
+	public map[string]struct{}
+}
+
+// NewInterceptor creates an interceptor. public are full method names, e.g.
+// "/SOAMafia/Login", that may be called without a token.
+func NewInterceptor(tokens *Tokens, public ...string) *Interceptor {
+	i := &Interceptor{
+		tokens: tokens,
+		public: make(map[string]struct{}, len(public)),
+	}
+
+	for _, method := range public {
+		i.public[method] = struct{}{}
+	}
+
+	return i
+}
+
+func (i *Interceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := i.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (i *Interceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := i.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *Interceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if _, ok := i.public[method]; ok {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(metadataKey)
+	if len(values) == 0 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return nil, status.Error(codes.Unauthenticated, "missing token")
+	}
+
+	username, ok := i.tokens.Username(strings.TrimPrefix(values[0], bearerPrefix))
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+
+	return context.WithValue(ctx, usernameKey{}, username), nil
+}
+
+// Username returns the authenticated caller. It is empty for public methods.
+func Username(ctx context.Context) string {
+	username, _ := ctx.Value(usernameKey{}).(string)
+	return username
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
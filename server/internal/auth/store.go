@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/mcherdakov/soa-mafia/pkg/fileutil"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	ErrUserExists         = errors.New("user already exists")
+	ErrUnknownUser        = errors.New("unknown user")
+	ErrWrongPassword      = errors.New("wrong password")
+	ErrInvalidCredentials = errors.New("username and password must not be empty")
+)
+
+type account struct {
+	PasswordHash string `json:"password_hash"`
+}
+
+// FileStore keeps registered users in a JSON file. The whole file is
+// rewritten on every registration, which is fine for the number of players
+// a single server sees.
+type FileStore struct {
+	path string
+
+	mu       sync.Mutex
+	accounts map[string]account
+}
+
+func NewFileStore(path string) (*FileStore, error) {
+	store := &FileStore{
+		path:     path,
+		accounts: map[string]account{},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &store.accounts); err != nil {
+		return nil, fmt.Errorf("parse user store %s: %w", path, err)
+	}
+
+	return store, nil
+}
+
+func (s *FileStore) Register(username, password string) error {
+	if username == "" || password == "" {
+		return ErrInvalidCredentials
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.accounts[username]; ok {
+		return ErrUserExists
+	}
+
+	s.accounts[username] = account{PasswordHash: string(hash)}
+
+	if err := s.save(); err != nil {
+		delete(s.accounts, username)
+		return err
+	}
+
+	return nil
+}
+
+func (s *FileStore) Check(username, password string) error {
+	s.mu.Lock()
+	acc, ok := s.accounts[username]
+	s.mu.Unlock()
+
+	if !ok {
+		return ErrUnknownUser
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(acc.PasswordHash), []byte(password)); err != nil {
+		return ErrWrongPassword
+	}
+
+	return nil
+}
+
+func (s *FileStore) save() error {
+	return fileutil.WriteJSONAtomic(s.path, s.accounts)
+}
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+type token struct {
+	username  string
+	expiresAt time.Time
+}
+
+// Tokens issues opaque bearer tokens to logged in users. Tokens live in
+// memory only, so players log in again after a server restart.
+type Tokens struct {
+	ttl time.Duration
+
+	mu     sync.Mutex
+	tokens map[string]token
+}
+
+func NewTokens(ttl time.Duration) *Tokens {
+	return &Tokens{
+		ttl:    ttl,
+		tokens: map[string]token{},
+	}
+}
+
+func (t *Tokens) Issue(username string) (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	value := hex.EncodeToString(b)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.evict(time.Now())
+	t.tokens[value] = token{
+		username:  username,
+		expiresAt: time.Now().Add(t.ttl),
+	}
+
+	return value, nil
+}
+
+// Username returns the owner of a valid token.
+func (t *Tokens) Username(value string) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tok, ok := t.tokens[value]
+	if !ok || time.Now().After(tok.expiresAt) {
+		return "", false
+	}
+
+	return tok.username, true
+}
+
+func (t *Tokens) evict(now time.Time) {
+	for value, tok := range t.tokens {
+		if now.After(tok.expiresAt) {
+			delete(t.tokens, value)
+		}
+	}
+}
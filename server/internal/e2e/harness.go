@@ -0,0 +1,495 @@
+// Package e2e runs the game server in process for end-to-end tests. The
+// server listens on an in-memory connection, its sessions run on a fake clock
+// and are seeded with a fixed seed, so that a scripted game always hands out
+// the same roles and produces the same notifications. Every game that ends in
+// a scenario is also replayed from its event log, which must match the log.
+package e2e
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/pkg/clock"
+	"github.com/mcherdakov/soa-mafia/server/internal/auth"
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/history"
+	"github.com/mcherdakov/soa-mafia/server/internal/lobby"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+	"github.com/mcherdakov/soa-mafia/server/internal/queue"
+	"github.com/mcherdakov/soa-mafia/server/internal/rpc"
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/encoding/prototext"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+const (
+	password = "password"
+	// waitTimeout bounds every wait of the harness in real time, so that a
+	// broken scenario fails instead of hanging.
+	waitTimeout = time.Second * 5
+	// notificationBuffer is how many notifications a player may receive
+	// before the scenario reads them.
+	notificationBuffer = 100
+)
+
+// Epoch is the time the fake clock starts at.
+var Epoch = time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+type Harness struct {
+	t        testing.TB
+	clock    *clock.Fake
+	client   proto.SOAMafiaClient
+	sessions *session.SessionManager
+}
+
+// Start runs a server that plays its public queue sessions with cfg. The
+// spectators may watch sessions in omniscient mode. The server is stopped
+// when the test ends.
+func Start(t testing.TB, cfg session.Config, seed int64, spectators ...string) *Harness {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	users, err := auth.NewFileStore(filepath.Join(dir, "users.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	games, err := history.Open(filepath.Join(dir, "history.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clk := clock.NewFake(Epoch)
+	registry := models.NewRegistry()
+
+	sessionManager := session.NewSessionManager(time.Minute, registry, clk, rand.New(rand.NewSource(seed)))
+	sessionManager.OnFinished(func(result session.Result) {
+		if _, err := games.Save(result); err != nil {
+			t.Errorf("session %d: can not save game: %v", result.SessionID, err)
+		}
+	})
+
+	logs := &eventLogs{events: map[int64][]session.Event{}}
+	sessionManager.OnEvent(logs.append)
+	t.Cleanup(func() {
+		logs.verify(t)
+	})
+
+	go sessionManager.Run()
+
+	// the queue matches players as soon as they connect, the periodic
+	// retries are only needed by matchmakers that wait
+	q := queue.NewQueue(sessionManager.Chan(), cfg, queue.FIFO{}, registry)
+
+	tokens := auth.NewTokens(time.Hour)
+	interceptor := auth.NewInterceptor(tokens, rpc.PublicMethods...)
+
+	s := grpc.NewServer(
+		grpc.UnaryInterceptor(interceptor.Unary()),
+		grpc.StreamInterceptor(interceptor.Stream()),
+	)
+	proto.RegisterSOAMafiaServer(
+		s,
+		rpc.NewSOAMafiaServer(
+			q,
+			lobby.NewLobbies(sessionManager.Chan(), cfg, registry),
+			sessionManager,
+			games,
+			users,
+			tokens,
+			spectators,
+		),
+	)
+
+	listener := bufconn.Listen(1 << 20)
+	go s.Serve(listener)
+
+	conn, err := grpc.Dial(
+		"bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+		games.Close()
+	})
+
+	return &Harness{
+		t:        t,
+		clock:    clk,
+		client:   proto.NewSOAMafiaClient(conn),
+		sessions: sessionManager,
+	}
+}
+
+// eventLogs collects the event log of every session of the scenario.
+type eventLogs struct {
+	mu     sync.Mutex
+	events map[int64][]session.Event
+}
+
+func (l *eventLogs) append(sessionID int64, e session.Event) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.events[sessionID] = append(l.events[sessionID], e)
+}
+
+// verify replays every session that has ended and compares the replay with
+// its log. The end of the game is logged before the players are notified, so
+// the log of a game the scenario has seen to the end is complete.
+func (l *eventLogs) verify(t testing.TB) {
+	t.Helper()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for sessionID, events := range l.events {
+		if events[len(events)-1].Type != session.EventGameEnded {
+			continue
+		}
+
+		replayed, err := session.Replay(events)
+		if err != nil {
+			t.Errorf("session %d: replay: %v", sessionID, err)
+			continue
+		}
+
+		if err := session.Verify(events, replayed); err != nil {
+			t.Errorf("session %d: replay: %v", sessionID, err)
+		}
+	}
+}
+
+// Now returns the time of the fake clock.
+func (h *Harness) Now() time.Time {
+	return h.clock.Now()
+}
+
+// Advance moves the clock forward by d once the server waits for exactly d,
+// e.g. for the intro delay or a phase deadline.
+func (h *Harness) Advance(d time.Duration) {
+	h.t.Helper()
+
+	deadline := time.Now().Add(waitTimeout)
+	for !h.clock.Waiting(d) {
+		if time.Now().After(deadline) {
+			h.t.Fatalf("nothing waits for %s", d)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	h.clock.Advance(d)
+}
+
+// Player registers a new user and logs them in.
+func (h *Harness) Player(username string) *Player {
+	h.t.Helper()
+
+	out, err := h.client.Register(context.Background(), &proto.RegisterIn{
+		Username: username,
+		Password: password,
+	})
+	if err != nil {
+		h.t.Fatalf("register %s: %v", username, err)
+	}
+
+	return &Player{
+		Username: username,
+		h:        h,
+		ctx:      metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+out.Token),
+	}
+}
+
+// Players registers a user for every name.
+func (h *Harness) Players(usernames ...string) []*Player {
+	h.t.Helper()
+
+	players := make([]*Player, 0, len(usernames))
+	for _, username := range usernames {
+		players = append(players, h.Player(username))
+	}
+
+	return players
+}
+
+// Queue connects the players to the public queue one by one, in order.
+func (h *Harness) Queue(players ...*Player) {
+	h.t.Helper()
+
+	for _, p := range players {
+		if err := p.ConnectQueue(); err != nil {
+			h.t.Fatalf("%s: connect queue: %v", p.Username, err)
+		}
+	}
+}
+
+// Client returns a client that is not logged in.
+func (h *Harness) Client() proto.SOAMafiaClient {
+	return h.client
+}
+
+type notificationStream interface {
+	Recv() (*proto.Notifications, error)
+}
+
+// playerStream is a notification stream the player is in, e.g. the queue or
+// a lobby.
+type playerStream interface {
+	notificationStream
+	Header() (metadata.MD, error)
+}
+
+type Player struct {
+	Username string
+	// SessionID and ReconnectToken are taken from the greeting of the
+	// session once the player receives it.
+	SessionID      int64
+	ReconnectToken string
+
+	h             *Harness
+	ctx           context.Context
+	notifications chan *proto.Notifications
+	// hangUp closes the notification stream.
+	hangUp context.CancelFunc
+}
+
+// Context carries the token of the player.
+func (p *Player) Context() context.Context {
+	return p.ctx
+}
+
+// ConnectQueue joins the public queue. It returns once the server has added
+// the player to the queue, so that the players are queued in the order of
+// the calls.
+func (p *Player) ConnectQueue() error {
+	ctx, hangUp := context.WithCancel(p.ctx)
+
+	stream, err := p.h.client.ConnectQueue(ctx, &proto.ConnectQueueIn{})
+	if err != nil {
+		hangUp()
+		return err
+	}
+
+	// the server replies with the first notification only after the user
+	// is queued
+	return p.await(stream, hangUp)
+}
+
+// CreateLobby opens a private lobby hosted by the player. The invite code
+// comes with the first lobby notification.
+func (p *Player) CreateLobby(settings *proto.LobbySettings) error {
+	ctx, hangUp := context.WithCancel(p.ctx)
+
+	stream, err := p.h.client.CreateLobby(ctx, &proto.CreateLobbyIn{Settings: settings})
+	if err != nil {
+		hangUp()
+		return err
+	}
+
+	return p.await(stream, hangUp)
+}
+
+// JoinLobby joins the lobby with the invite code. It returns once the server
+// has added the player to the lobby.
+func (p *Player) JoinLobby(code string) error {
+	ctx, hangUp := context.WithCancel(p.ctx)
+
+	stream, err := p.h.client.JoinLobby(ctx, &proto.JoinLobbyIn{InviteCode: code})
+	if err != nil {
+		hangUp()
+		return err
+	}
+
+	return p.await(stream, hangUp)
+}
+
+// StartLobby starts the game of the lobby the player hosts.
+func (p *Player) StartLobby(code string) error {
+	_, err := p.h.client.StartLobby(p.ctx, &proto.StartLobbyIn{InviteCode: code})
+
+	return err
+}
+
+// await starts listening to the stream once the server has accepted the
+// call, a rejected call ends with trailers only.
+func (p *Player) await(stream playerStream, hangUp context.CancelFunc) error {
+	if header, err := stream.Header(); err != nil || len(header) == 0 {
+		_, err := stream.Recv()
+		hangUp()
+		return err
+	}
+
+	p.hangUp = hangUp
+	p.listen(stream)
+
+	return nil
+}
+
+// Disconnect closes the notification stream of the player and returns once
+// the session considers them away.
+func (p *Player) Disconnect() {
+	p.h.t.Helper()
+
+	p.hangUp()
+
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		if !p.h.sessions.SessionByID(p.SessionID).IsConnected(p.Username) {
+			return
+		}
+
+		if time.Now().After(deadline) {
+			p.h.t.Fatalf("%s: still connected after %s", p.Username, waitTimeout)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// Reconnect returns the player to their session with the token of the
+// session greeting. The greeting and the current phase are replayed.
+func (p *Player) Reconnect() error {
+	ctx, hangUp := context.WithCancel(p.ctx)
+
+	stream, err := p.h.client.Reconnect(ctx, &proto.ReconnectIn{
+		SessionId:      p.SessionID,
+		ReconnectToken: p.ReconnectToken,
+	})
+	if err != nil {
+		hangUp()
+		return err
+	}
+
+	return p.await(stream, hangUp)
+}
+
+// Watch starts watching the session instead of playing.
+func (p *Player) Watch(sessionID int64, omniscient bool) error {
+	stream, err := p.h.client.WatchSession(p.ctx, &proto.WatchSessionIn{
+		SessionId:  sessionID,
+		Omniscient: omniscient,
+	})
+	if err != nil {
+		return err
+	}
+
+	if header, err := stream.Header(); err != nil || len(header) == 0 {
+		_, err := stream.Recv()
+		return err
+	}
+
+	p.listen(stream)
+
+	return nil
+}
+
+func (p *Player) listen(stream notificationStream) {
+	// a reconnect replaces the channel, the old stream must not write to the
+	// new one
+	notifications := make(chan *proto.Notifications, notificationBuffer)
+	p.notifications = notifications
+
+	go func() {
+		defer close(notifications)
+
+		for {
+			n, err := stream.Recv()
+			if err != nil {
+				return
+			}
+
+			notifications <- n
+		}
+	}()
+}
+
+// Next returns the next notification of the player. The reconnect token of a
+// session greeting is remembered and cleared, since it is random.
+func (p *Player) Next() *proto.Notifications {
+	p.h.t.Helper()
+
+	select {
+	case n, ok := <-p.notifications:
+		if !ok {
+			p.h.t.Fatalf("%s: notification stream closed", p.Username)
+		}
+
+		if enter := n.GetEnterSession(); enter != nil {
+			p.SessionID = enter.SessionId
+			p.ReconnectToken = enter.ReconnectToken
+			enter.ReconnectToken = ""
+		}
+
+		return n
+	case <-time.After(waitTimeout):
+		p.h.t.Fatalf("%s: no notification in %s", p.Username, waitTimeout)
+	}
+
+	return nil
+}
+
+// Expect checks that the next notifications of the player are exactly want.
+func (p *Player) Expect(want ...*proto.Notifications) {
+	p.h.t.Helper()
+
+	for i, w := range want {
+		got := p.Next()
+		if !protobuf.Equal(got, w) {
+			p.h.t.Fatalf(
+				"%s: notification %d:\ngot:  %s\nwant: %s",
+				p.Username, i, prototext.Format(got), prototext.Format(w),
+			)
+		}
+	}
+}
+
+// ExpectNothing checks that the player has no unread notifications.
+func (p *Player) ExpectNothing() {
+	p.h.t.Helper()
+
+	select {
+	case n, ok := <-p.notifications:
+		if ok {
+			p.h.t.Fatalf("%s: unexpected notification: %s", p.Username, prototext.Format(n))
+		}
+	default:
+	}
+}
+
+// Send submits the command to the session of the player.
+func (p *Player) Send(cmd *proto.Commands) error {
+	_, err := p.h.client.SendCommand(p.ctx, &proto.SendCommandIn{
+		Command:   cmd,
+		SessionId: p.SessionID,
+	})
+
+	return err
+}
+
+// Do submits the command and fails the test if the server rejects it.
+func (p *Player) Do(cmd *proto.Commands) {
+	p.h.t.Helper()
+
+	if err := p.Send(cmd); err != nil {
+		p.h.t.Fatalf("%s: %v", p.Username, err)
+	}
+}
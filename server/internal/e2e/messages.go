@@ -0,0 +1,200 @@
+package e2e
+
+import (
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+// The helpers below build the commands and notifications of the scenarios.
+
+func Pass() *proto.Commands {
+	return &proto.Commands{
+		Command: &proto.Commands_PassCommand{PassCommand: &proto.PassCommand{}},
+	}
+}
+
+func Vote(username string) *proto.Commands {
+	return &proto.Commands{
+		Command: &proto.Commands_VoteCommand{VoteCommand: &proto.VoteCommand{Username: username}},
+	}
+}
+
+func Abstain() *proto.Commands {
+	return &proto.Commands{
+		Command: &proto.Commands_VoteCommand{VoteCommand: &proto.VoteCommand{Abstain: true}},
+	}
+}
+
+func Kill(username string) *proto.Commands {
+	return &proto.Commands{
+		Command: &proto.Commands_KillCommand{KillCommand: &proto.KillCommand{Username: username}},
+	}
+}
+
+func Check(username string) *proto.Commands {
+	return &proto.Commands{
+		Command: &proto.Commands_CheckCommand{CheckCommand: &proto.CheckCommand{Username: username}},
+	}
+}
+
+func Heal(username string) *proto.Commands {
+	return &proto.Commands{
+		Command: &proto.Commands_HealCommand{HealCommand: &proto.HealCommand{Username: username}},
+	}
+}
+
+func UserConnected(username string, current ...string) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_UserConnected{
+			UserConnected: &proto.UserConnectedNotification{
+				Username: username,
+				Current:  current,
+			},
+		},
+	}
+}
+
+// EnterSession is the greeting without the reconnect token, which Player.Next
+// clears.
+func EnterSession(sessionID int64, role proto.Role, teammates ...string) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_EnterSession{
+			EnterSession: &proto.EnterSessionNotification{
+				SessionId: sessionID,
+				Role:      role,
+				Teammates: teammates,
+			},
+		},
+	}
+}
+
+// RoundStart announces a day. killed is empty if nobody was killed at night.
+func RoundStart(day int64, phase proto.Phase, deadline time.Time, killed string, remaining ...string) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_RoundStart{
+			RoundStart: &proto.RoundStartNotification{
+				Day:            day,
+				KilledUsername: optional(killed),
+				Remaining:      remaining,
+				Deadline:       deadline.UnixMilli(),
+				Phase:          phase,
+			},
+		},
+	}
+}
+
+// NightTime announces a night. votedOut is empty if nobody was voted out.
+func NightTime(phase proto.Phase, deadline time.Time, votedOut string, votes []*proto.Vote, remaining ...string) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_NightTime{
+			NightTime: &proto.NightTimeNotification{
+				VotedOut:  optional(votedOut),
+				Remaining: remaining,
+				Deadline:  deadline.UnixMilli(),
+				Votes:     votes,
+				Phase:     phase,
+			},
+		},
+	}
+}
+
+// Votes builds a tally from voter and target pairs, an empty target is an
+// abstention.
+func Votes(pairs ...string) []*proto.Vote {
+	votes := make([]*proto.Vote, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		votes = append(votes, &proto.Vote{
+			Voter:    pairs[i],
+			Username: optional(pairs[i+1]),
+		})
+	}
+
+	return votes
+}
+
+func InvestigationResult(username string, isMafia bool) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_InvestigationResult{
+			InvestigationResult: &proto.InvestigationResultNotification{
+				Username: username,
+				IsMafia:  isMafia,
+			},
+		},
+	}
+}
+
+// Spectate starts the notifications of a spectator, roles are given in
+// session order and only in omniscient mode.
+func Spectate(sessionID int64, omniscient bool, roles ...*proto.PlayerRole) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_Spectate{
+			Spectate: &proto.SpectateNotification{
+				SessionId:  sessionID,
+				Omniscient: omniscient,
+				Roles:      roles,
+			},
+		},
+	}
+}
+
+func PlayerRole(username string, role proto.Role) *proto.PlayerRole {
+	return &proto.PlayerRole{
+		Username: username,
+		Role:     role,
+	}
+}
+
+func NightAction(username string, role proto.Role, target string) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_NightAction{
+			NightAction: &proto.NightActionNotification{
+				Username: username,
+				Role:     role,
+				Target:   target,
+			},
+		},
+	}
+}
+
+func Result(winner proto.Role) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_ResultNotification{
+			ResultNotification: &proto.ResultNotification{
+				Winner: winner,
+			},
+		},
+	}
+}
+
+func Aborted(reason string) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_ResultNotification{
+			ResultNotification: &proto.ResultNotification{
+				Aborted: true,
+				Reason:  reason,
+			},
+		},
+	}
+}
+
+func Lobby(code, host string, capacity int32, players ...string) *proto.Notifications {
+	return &proto.Notifications{
+		Notification: &proto.Notifications_Lobby{
+			Lobby: &proto.LobbyNotification{
+				InviteCode: code,
+				Host:       host,
+				Players:    players,
+				Capacity:   capacity,
+			},
+		},
+	}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
@@ -0,0 +1,605 @@
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+// seed hands out the roles of the scenarios. With four players alice is the
+// detective and bob is the mafia, with five players alice is the doctor, bob
+// is the detective and carol is the mafia.
+const seed = 1
+
+func expect(t *testing.T, players []*Player, want ...*proto.Notifications) {
+	t.Helper()
+
+	for _, p := range players {
+		p.Expect(want...)
+	}
+}
+
+func do(t *testing.T, players []*Player, cmd *proto.Commands) {
+	t.Helper()
+
+	for _, p := range players {
+		p.Do(cmd)
+	}
+}
+
+func usernames(players []*Player) []string {
+	names := make([]string, 0, len(players))
+	for _, p := range players {
+		names = append(names, p.Username)
+	}
+
+	return names
+}
+
+// startGame queues the players and skips the introduction. The greetings are
+// checked by the caller.
+func startGame(h *Harness, players []*Player) {
+	h.Queue(players...)
+
+	for i, p := range players {
+		for _, joined := range players[i : len(players)-1] {
+			current := make([]string, 0, len(players))
+			for _, other := range players {
+				current = append(current, other.Username)
+				if other == joined {
+					break
+				}
+			}
+
+			p.Expect(UserConnected(joined.Username, current...))
+		}
+	}
+}
+
+func TestCiviliansVoteOutMafia(t *testing.T) {
+	cfg := session.DefaultConfig()
+	h := Start(t, cfg, seed)
+
+	players := h.Players("alice", "bob", "carol", "dave")
+	alice, bob, carol, dave := players[0], players[1], players[2], players[3]
+
+	startGame(h, players)
+
+	alice.Expect(EnterSession(1, proto.Role_DETECITVE))
+	bob.Expect(EnterSession(1, proto.Role_MAFIA))
+	carol.Expect(EnterSession(1, proto.Role_CIVILIAN))
+	dave.Expect(EnterSession(1, proto.Role_CIVILIAN))
+
+	h.Advance(session.IntroDelay)
+
+	expect(t, players, RoundStart(1, proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", "alice", "bob", "carol", "dave"))
+	do(t, players, Pass())
+
+	expect(t, players, NightTime(proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", nil, "alice", "bob", "carol", "dave"))
+	do(t, players, Pass())
+
+	expect(t, players, RoundStart(2, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "", "alice", "bob", "carol", "dave"))
+	alice.Do(Vote("bob"))
+	bob.Do(Vote("alice"))
+	carol.Do(Vote("bob"))
+	dave.Do(Vote("bob"))
+
+	bob.Expect(Spectate(1, true,
+		PlayerRole("alice", proto.Role_DETECITVE),
+		PlayerRole("bob", proto.Role_MAFIA),
+		PlayerRole("carol", proto.Role_CIVILIAN),
+		PlayerRole("dave", proto.Role_CIVILIAN),
+	))
+	expect(t, players, Result(proto.Role_CIVILIAN))
+
+	for _, p := range players {
+		p.ExpectNothing()
+	}
+}
+
+func TestRunoffIsRecorded(t *testing.T) {
+	cfg := session.DefaultConfig()
+	cfg.TieRule = session.TieRunoff
+	h := Start(t, cfg, seed)
+
+	players := h.Players("alice", "bob", "carol", "dave")
+	alice, bob, carol, dave := players[0], players[1], players[2], players[3]
+
+	startGame(h, players)
+
+	for _, p := range players {
+		p.Next()
+	}
+
+	h.Advance(session.IntroDelay)
+
+	expect(t, players, RoundStart(1, proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", "alice", "bob", "carol", "dave"))
+	do(t, players, Pass())
+
+	expect(t, players, NightTime(proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", nil, "alice", "bob", "carol", "dave"))
+	do(t, players, Pass())
+
+	expect(t, players, RoundStart(2, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "", "alice", "bob", "carol", "dave"))
+	alice.Do(Vote("bob"))
+	bob.Do(Vote("alice"))
+	carol.Do(Vote("alice"))
+	dave.Do(Vote("bob"))
+
+	for _, p := range players {
+		if p.Next().GetRunoff() == nil {
+			t.Fatalf("%s: no runoff", p.Username)
+		}
+	}
+
+	alice.Do(Vote("bob"))
+	bob.Do(Vote("alice"))
+	carol.Do(Vote("bob"))
+	dave.Do(Vote("bob"))
+
+	bob.Next()
+	expect(t, players, Result(proto.Role_CIVILIAN))
+
+	// the game is saved once the players are notified
+	var games []*proto.GameSummary
+
+	deadline := time.Now().Add(waitTimeout)
+	for len(games) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the game is not saved")
+		}
+
+		out, err := h.Client().ListGames(alice.Context(), &proto.ListGamesIn{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		games = out.Games
+	}
+
+	record, err := h.Client().GetGame(alice.Context(), &proto.GetGameIn{GameId: games[0].GameId})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &proto.DayRecord{
+		Day:         2,
+		Votes:       Votes("alice", "bob", "bob", "alice", "carol", "alice", "dave", "bob"),
+		RunoffVotes: Votes("alice", "bob", "bob", "alice", "carol", "bob", "dave", "bob"),
+		VotedOut:    optional("bob"),
+	}
+	if got := record.Days[len(record.Days)-1]; !protobuf.Equal(got, want) {
+		t.Fatalf("day record:\ngot:  %v\nwant: %v", got, want)
+	}
+}
+
+func TestDoctorSavesAndDetectiveFindsMafia(t *testing.T) {
+	cfg := session.DefaultConfig()
+	cfg.Capacity = 5
+	h := Start(t, cfg, seed)
+
+	players := h.Players("alice", "bob", "carol", "dave", "erin")
+	alice, bob, carol, dave, erin := players[0], players[1], players[2], players[3], players[4]
+
+	startGame(h, players)
+
+	alice.Expect(EnterSession(1, proto.Role_DOCTOR))
+	bob.Expect(EnterSession(1, proto.Role_DETECITVE))
+	carol.Expect(EnterSession(1, proto.Role_MAFIA))
+	dave.Expect(EnterSession(1, proto.Role_CIVILIAN))
+	erin.Expect(EnterSession(1, proto.Role_CIVILIAN))
+
+	h.Advance(session.IntroDelay)
+
+	everyone := []string{"alice", "bob", "carol", "dave", "erin"}
+
+	expect(t, players, RoundStart(1, proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", everyone...))
+	do(t, players, Pass())
+
+	expect(t, players, NightTime(proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", nil, everyone...))
+	do(t, players, Pass())
+
+	expect(t, players, RoundStart(2, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "", everyone...))
+	do(t, players, Abstain())
+
+	expect(t, players, NightTime(
+		proto.Phase_PHASE_NIGHT,
+		h.Now().Add(cfg.NightTimeout),
+		"",
+		Votes("alice", "", "bob", "", "carol", "", "dave", "", "erin", ""),
+		everyone...,
+	))
+	carol.Do(Kill("erin"))
+	alice.Do(Heal("erin"))
+	bob.Do(Check("carol"))
+
+	bob.Expect(InvestigationResult("carol", true))
+	expect(t, players, RoundStart(3, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "", everyone...))
+
+	alice.Do(Vote("carol"))
+	bob.Do(Vote("carol"))
+	carol.Do(Vote("bob"))
+	dave.Do(Vote("carol"))
+	erin.Do(Vote("carol"))
+
+	carol.Expect(Spectate(1, true,
+		PlayerRole("alice", proto.Role_DOCTOR),
+		PlayerRole("bob", proto.Role_DETECITVE),
+		PlayerRole("carol", proto.Role_MAFIA),
+		PlayerRole("dave", proto.Role_CIVILIAN),
+		PlayerRole("erin", proto.Role_CIVILIAN),
+	))
+	expect(t, players, Result(proto.Role_CIVILIAN))
+
+	for _, p := range players {
+		p.ExpectNothing()
+	}
+}
+
+func TestMissedDeadlines(t *testing.T) {
+	cfg := session.DefaultConfig()
+	h := Start(t, cfg, seed)
+
+	players := h.Players("alice", "bob", "carol", "dave")
+	everyone := []string{"alice", "bob", "carol", "dave"}
+
+	startGame(h, players)
+
+	for _, p := range players {
+		p.Next()
+	}
+
+	h.Advance(session.IntroDelay)
+
+	expect(t, players, RoundStart(1, proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", everyone...))
+	h.Advance(cfg.DiscussionTimeout)
+
+	expect(t, players, NightTime(proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", nil, everyone...))
+	h.Advance(cfg.DiscussionTimeout)
+
+	expect(t, players, RoundStart(2, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "", everyone...))
+	h.Advance(cfg.VoteTimeout)
+
+	expect(t, players, NightTime(
+		proto.Phase_PHASE_NIGHT,
+		h.Now().Add(cfg.NightTimeout),
+		"",
+		Votes("alice", "", "bob", "", "carol", "", "dave", ""),
+		everyone...,
+	))
+	h.Advance(cfg.NightTimeout)
+
+	expect(t, players, RoundStart(3, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "", everyone...))
+
+	for _, p := range players {
+		p.ExpectNothing()
+	}
+}
+
+func TestReconnectGrace(t *testing.T) {
+	cfg := session.DefaultConfig()
+	cfg.ReconnectGrace = time.Second * 3
+	h := Start(t, cfg, seed)
+
+	players := h.Players("alice", "bob", "carol", "dave")
+	alice, bob, carol, dave := players[0], players[1], players[2], players[3]
+	others := players[:3]
+
+	startGame(h, players)
+
+	for _, p := range players {
+		p.Next()
+	}
+
+	h.Advance(session.IntroDelay)
+
+	everyone := []string{"alice", "bob", "carol", "dave"}
+
+	expect(t, players, RoundStart(1, proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", everyone...))
+
+	dave.Disconnect()
+	do(t, others, Pass())
+
+	// the phase keeps waiting for dave during the grace period, which is
+	// checked every second
+	h.Advance(time.Second)
+	h.Advance(time.Second)
+	alice.ExpectNothing()
+
+	h.Advance(time.Second)
+
+	nightTime := NightTime(proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", nil, everyone...)
+	expect(t, others, nightTime)
+
+	if err := dave.Reconnect(); err != nil {
+		t.Fatal(err)
+	}
+
+	dave.Expect(EnterSession(1, proto.Role_CIVILIAN), nightTime)
+
+	// dave is back, so the night waits for him again
+	do(t, []*Player{alice, bob, carol, dave}, Pass())
+
+	expect(t, players, RoundStart(2, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "", everyone...))
+
+	for _, p := range players {
+		p.ExpectNothing()
+	}
+}
+
+func TestSpectators(t *testing.T) {
+	cfg := session.DefaultConfig()
+	h := Start(t, cfg, seed, "gm")
+
+	players := h.Players("alice", "bob", "carol", "dave")
+	alice, bob, carol, dave := players[0], players[1], players[2], players[3]
+	everyone := []string{"alice", "bob", "carol", "dave"}
+	roles := []*proto.PlayerRole{
+		PlayerRole("alice", proto.Role_DETECITVE),
+		PlayerRole("bob", proto.Role_MAFIA),
+		PlayerRole("carol", proto.Role_CIVILIAN),
+		PlayerRole("dave", proto.Role_CIVILIAN),
+	}
+
+	startGame(h, players)
+
+	for _, p := range players {
+		p.Next()
+	}
+
+	mallory := h.Player("mallory")
+	if err := mallory.Watch(1, true); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("omniscient watch of an unapproved spectator: %v", err)
+	}
+
+	eve, gm := h.Player("eve"), h.Player("gm")
+	if err := eve.Watch(1, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := gm.Watch(1, true); err != nil {
+		t.Fatal(err)
+	}
+
+	eve.Expect(Spectate(1, false))
+	gm.Expect(Spectate(1, true, roles...))
+
+	spectators := []*Player{eve, gm}
+	watching := append(spectators, players...)
+
+	h.Advance(session.IntroDelay)
+
+	expect(t, watching, RoundStart(1, proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", everyone...))
+	do(t, players, Pass())
+
+	expect(t, watching, NightTime(proto.Phase_PHASE_PASS, h.Now().Add(cfg.DiscussionTimeout), "", nil, everyone...))
+	do(t, players, Pass())
+
+	expect(t, watching, RoundStart(2, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "", everyone...))
+	do(t, players, Abstain())
+
+	expect(t, watching, NightTime(
+		proto.Phase_PHASE_NIGHT,
+		h.Now().Add(cfg.NightTimeout),
+		"",
+		Votes("alice", "", "bob", "", "carol", "", "dave", ""),
+		everyone...,
+	))
+	bob.Do(Kill("carol"))
+	alice.Do(Check("dave"))
+
+	gm.Expect(
+		NightAction("bob", proto.Role_MAFIA, "carol"),
+		NightAction("alice", proto.Role_DETECITVE, "dave"),
+	)
+	carol.Expect(Spectate(1, true, roles...))
+	alice.Expect(InvestigationResult("dave", false))
+
+	alive := []string{"alice", "bob", "dave"}
+	expect(t, watching, RoundStart(3, proto.Phase_PHASE_VOTE, h.Now().Add(cfg.VoteTimeout), "carol", alive...))
+
+	alice.Do(Vote("bob"))
+	bob.Do(Vote("dave"))
+	dave.Do(Vote("bob"))
+
+	bob.Expect(Spectate(1, true, roles...))
+	expect(t, watching, Result(proto.Role_CIVILIAN))
+
+	for _, p := range watching {
+		p.ExpectNothing()
+	}
+}
+
+func TestMembership(t *testing.T) {
+	h := Start(t, session.DefaultConfig(), seed)
+
+	players := h.Players("alice", "bob", "carol", "dave")
+	startGame(h, players)
+
+	// the roles are known once the greetings are sent
+	for _, p := range players {
+		p.Next()
+	}
+
+	eve := h.Player("eve")
+
+	for _, tc := range []struct {
+		player *Player
+		want   *proto.Membership
+	}{
+		{players[0], &proto.Membership{Username: "alice", Member: true, Alive: true, Role: proto.Role_DETECITVE}},
+		{players[1], &proto.Membership{Username: "bob", Member: true, Alive: true, Role: proto.Role_MAFIA}},
+		{eve, &proto.Membership{Username: "eve"}},
+	} {
+		got, err := h.Client().GetMembership(tc.player.Context(), &proto.MembershipIn{SessionId: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !protobuf.Equal(got, tc.want) {
+			t.Fatalf("membership of %s: got %v, want %v", tc.player.Username, got, tc.want)
+		}
+	}
+
+	_, err := h.Client().GetMembership(eve.Context(), &proto.MembershipIn{SessionId: 2})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("membership in an unknown session: %v", err)
+	}
+
+	_, err = h.Client().GetMembership(context.Background(), &proto.MembershipIn{SessionId: 1})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("membership without a token: %v", err)
+	}
+}
+
+func TestAbortedSession(t *testing.T) {
+	// there are no roles for three players, so the session fails right away
+	cfg := session.DefaultConfig()
+	cfg.Capacity = 3
+	h := Start(t, cfg, seed)
+
+	players := h.Players("alice", "bob", "carol")
+	startGame(h, players)
+
+	expect(t, players, Aborted("unsupported session capacity 3"))
+
+	// the session stops routing players once they are notified, but stays
+	// listed as aborted
+	deadline := time.Now().Add(waitTimeout)
+	for {
+		_, err := h.Client().GetMembership(players[0].Context(), &proto.MembershipIn{SessionId: 1})
+		if status.Code(err) == codes.NotFound {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatalf("aborted session still routes players: %v", err)
+		}
+
+		time.Sleep(time.Millisecond)
+	}
+
+	if err := players[0].Watch(1, false); status.Code(err) != codes.NotFound {
+		t.Fatalf("watching an aborted session: %v", err)
+	}
+
+	if info, ok := h.sessions.Lookup(1); !ok || info.State != session.StateAborted {
+		t.Fatalf("aborted session: got %v, %t", info, ok)
+	}
+}
+
+func TestLobby(t *testing.T) {
+	h := Start(t, session.DefaultConfig(), seed)
+
+	players := h.Players("alice", "bob", "carol", "dave", "erin")
+	alice, bob, carol, dave, erin := players[0], players[1], players[2], players[3], players[4]
+	frank := h.Player("frank")
+
+	settings := &proto.LobbySettings{
+		Capacity:            5,
+		DiscussionTimeoutMs: 20_000,
+		VoteTimeoutMs:       30_000,
+		NightTimeoutMs:      40_000,
+	}
+	discussion, vote, night := 20*time.Second, 30*time.Second, 40*time.Second
+
+	if err := alice.CreateLobby(settings); err != nil {
+		t.Fatal(err)
+	}
+	code := alice.Next().GetLobby().GetInviteCode()
+
+	if err := frank.JoinLobby("NOCODE"); status.Code(err) != codes.NotFound {
+		t.Fatalf("joining an unknown lobby: %v", err)
+	}
+
+	for i, p := range players[1:3] {
+		if err := p.JoinLobby(code); err != nil {
+			t.Fatal(err)
+		}
+
+		joined := players[:i+2]
+		expect(t, joined, Lobby(code, "alice", 5, usernames(joined)...))
+	}
+
+	if err := bob.StartLobby(code); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("start by a guest: %v", err)
+	}
+
+	if err := alice.StartLobby(code); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("start with three players: %v", err)
+	}
+
+	for i, p := range players[3:] {
+		if err := p.JoinLobby(code); err != nil {
+			t.Fatal(err)
+		}
+
+		joined := players[:i+4]
+		expect(t, joined, Lobby(code, "alice", 5, usernames(joined)...))
+	}
+
+	if err := frank.JoinLobby(code); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("joining a full lobby: %v", err)
+	}
+
+	if err := alice.StartLobby(code); err != nil {
+		t.Fatal(err)
+	}
+
+	alice.Expect(EnterSession(1, proto.Role_DOCTOR))
+	bob.Expect(EnterSession(1, proto.Role_DETECITVE))
+	carol.Expect(EnterSession(1, proto.Role_MAFIA))
+	dave.Expect(EnterSession(1, proto.Role_CIVILIAN))
+	erin.Expect(EnterSession(1, proto.Role_CIVILIAN))
+
+	h.Advance(session.IntroDelay)
+
+	everyone := usernames(players)
+
+	// the phases run on the timers of the lobby
+	expect(t, players, RoundStart(1, proto.Phase_PHASE_PASS, h.Now().Add(discussion), "", everyone...))
+	do(t, players, Pass())
+
+	expect(t, players, NightTime(proto.Phase_PHASE_PASS, h.Now().Add(discussion), "", nil, everyone...))
+	do(t, players, Pass())
+
+	expect(t, players, RoundStart(2, proto.Phase_PHASE_VOTE, h.Now().Add(vote), "", everyone...))
+	do(t, players, Abstain())
+
+	expect(t, players, NightTime(
+		proto.Phase_PHASE_NIGHT,
+		h.Now().Add(night),
+		"",
+		Votes("alice", "", "bob", "", "carol", "", "dave", "", "erin", ""),
+		everyone...,
+	))
+	carol.Do(Kill("erin"))
+	alice.Do(Heal("erin"))
+	bob.Do(Check("carol"))
+
+	bob.Expect(InvestigationResult("carol", true))
+	expect(t, players, RoundStart(3, proto.Phase_PHASE_VOTE, h.Now().Add(vote), "", everyone...))
+
+	alice.Do(Vote("carol"))
+	bob.Do(Vote("carol"))
+	carol.Do(Vote("bob"))
+	dave.Do(Vote("carol"))
+	erin.Do(Vote("carol"))
+
+	carol.Expect(Spectate(1, true,
+		PlayerRole("alice", proto.Role_DOCTOR),
+		PlayerRole("bob", proto.Role_DETECITVE),
+		PlayerRole("carol", proto.Role_MAFIA),
+		PlayerRole("dave", proto.Role_CIVILIAN),
+		PlayerRole("erin", proto.Role_CIVILIAN),
+	))
+	expect(t, players, Result(proto.Role_CIVILIAN))
+
+	for _, p := range players {
+		p.ExpectNothing()
+	}
+}
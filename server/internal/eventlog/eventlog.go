@@ -0,0 +1,112 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+)
+
+// Dir writes the events of every session into its own JSON lines file.
+// Files are named after the time the session was created and its ID, since
+// session IDs start over when the server restarts.
+type Dir struct {
+	path string
+
+	mu    sync.Mutex
+	files map[int64]*os.File
+}
+
+func NewDir(path string) (*Dir, error) {
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &Dir{
+		path:  path,
+		files: map[int64]*os.File{},
+	}, nil
+}
+
+// Append writes the event to the log of the session. The file is synced
+// after every event, so that the log survives a crash of the server.
+func (d *Dir) Append(sessionID int64, e session.Event) error {
+	f, err := d.file(sessionID, e)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	if err := f.Sync(); err != nil {
+		return err
+	}
+
+	if e.Type == session.EventGameEnded {
+		d.mu.Lock()
+		delete(d.files, sessionID)
+		d.mu.Unlock()
+
+		return f.Close()
+	}
+
+	return nil
+}
+
+func (d *Dir) file(sessionID int64, e session.Event) (*os.File, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if f, ok := d.files[sessionID]; ok {
+		return f, nil
+	}
+
+	if e.Type != session.EventSessionCreated {
+		return nil, fmt.Errorf("session %d: log does not start with %s", sessionID, session.EventSessionCreated)
+	}
+
+	name := fmt.Sprintf("%s-session-%d.jsonl", e.Time.Format("20060102-150405"), sessionID)
+
+	f, err := os.OpenFile(filepath.Join(d.path, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	d.files[sessionID] = f
+
+	return f, nil
+}
+
+// Read loads a log written by Dir.
+func Read(path string) ([]session.Event, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	events := []session.Event{}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e session.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s: event %d: %w", path, len(events)+1, err)
+		}
+
+		events = append(events, e)
+	}
+
+	return events, scanner.Err()
+}
@@ -0,0 +1,227 @@
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+	bolt "go.etcd.io/bbolt"
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+var ErrUnknownGame = errors.New("unknown game")
+
+var (
+	gamesBucket = []byte("games")
+	// playersBucket indexes games by player. Keys are the username, a zero
+	// byte and the big endian game ID, so that a player's games are sorted by
+	// ID.
+	playersBucket = []byte("players")
+)
+
+// Store keeps finished games in a BoltDB file. Game IDs are assigned by the
+// store since session IDs start over when the server restarts.
+type Store struct {
+	db *bolt.DB
+}
+
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{gamesBucket, playersBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Save stores the result of a finished session and returns its game ID.
+func (s *Store) Save(result session.Result) (int64, error) {
+	record := newRecord(result)
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		games := tx.Bucket(gamesBucket)
+
+		id, err := games.NextSequence()
+		if err != nil {
+			return err
+		}
+		record.GameId = int64(id)
+
+		data, err := protobuf.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		if err := games.Put(itob(record.GameId), data); err != nil {
+			return err
+		}
+
+		players := tx.Bucket(playersBucket)
+		for _, player := range record.Players {
+			if err := players.Put(playerKey(player.Username, record.GameId), nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return record.GameId, nil
+}
+
+func (s *Store) Get(gameID int64) (*proto.GameRecord, error) {
+	record := &proto.GameRecord{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(gamesBucket).Get(itob(gameID))
+		if data == nil {
+			return ErrUnknownGame
+		}
+
+		return protobuf.Unmarshal(data, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+// List returns up to limit most recent games of the player.
+func (s *Store) List(username string, limit int) ([]*proto.GameSummary, error) {
+	res := []*proto.GameSummary{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		games := tx.Bucket(gamesBucket)
+		prefix := playerKey(username, 0)[:len(username)+1]
+
+		c := tx.Bucket(playersBucket).Cursor()
+
+		// walk the player's keys backwards, starting after the largest ID
+		k, _ := c.Seek(playerKey(username, -1))
+		if k == nil {
+			k, _ = c.Last()
+		} else {
+			k, _ = c.Prev()
+		}
+
+		for ; k != nil && bytes.HasPrefix(k, prefix) && len(res) < limit; k, _ = c.Prev() {
+			record := &proto.GameRecord{}
+			if err := protobuf.Unmarshal(games.Get(k[len(prefix):]), record); err != nil {
+				return err
+			}
+
+			res = append(res, summary(record, username))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func newRecord(result session.Result) *proto.GameRecord {
+	record := &proto.GameRecord{
+		StartedAt:  result.StartedAt.UnixMilli(),
+		FinishedAt: result.FinishedAt.UnixMilli(),
+		Ruleset:    result.Ruleset,
+		Winner:     result.Winner,
+	}
+
+	for username, role := range result.Roles {
+		record.Players = append(record.Players, &proto.PlayerRole{
+			Username: username,
+			Role:     role,
+		})
+	}
+
+	sort.Slice(record.Players, func(i, j int) bool {
+		return record.Players[i].Username < record.Players[j].Username
+	})
+
+	for _, day := range result.Days {
+		dayRecord := &proto.DayRecord{
+			Day:         day.Number,
+			Votes:       day.Votes,
+			RunoffVotes: day.RunoffVotes,
+			VotedOut:    day.VotedOut,
+			Killed:      day.Killed,
+			Healed:      day.Healed,
+		}
+
+		for _, check := range day.Checks {
+			dayRecord.Checks = append(dayRecord.Checks, &proto.Check{
+				Detective: check.Detective,
+				Suspect:   check.Suspect,
+				IsMafia:   check.IsMafia,
+			})
+		}
+
+		record.Days = append(record.Days, dayRecord)
+	}
+
+	return record
+}
+
+func summary(record *proto.GameRecord, username string) *proto.GameSummary {
+	res := &proto.GameSummary{
+		GameId:     record.GameId,
+		FinishedAt: record.FinishedAt,
+		Winner:     record.Winner,
+	}
+
+	for _, player := range record.Players {
+		res.Players = append(res.Players, player.Username)
+
+		if player.Username == username {
+			res.Role = player.Role
+		}
+	}
+
+	return res
+}
+
+func itob(v int64) []byte {
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(v))
+
+	return b
+}
+
+// playerKey builds an index key. An ID of -1 sorts after every real ID of
+// the player.
+func playerKey(username string, gameID int64) []byte {
+	key := make([]byte, 0, len(username)+9)
+	key = append(key, username...)
+	key = append(key, 0)
+
+	return append(key, itob(gameID)...)
+}
@@ -0,0 +1,247 @@
+package lobby
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+)
+
+const (
+	codeLength = 6
+	// codeAlphabet leaves out characters that are easy to confuse, e.g. 0
+	// and O.
+	codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+)
+
+var (
+	ErrInvalidSettings = errors.New("invalid lobby settings")
+	ErrUnknownLobby    = errors.New("unknown invite code")
+	ErrLobbyFull       = errors.New("lobby is full")
+	ErrNotHost         = errors.New("only the host can start the lobby")
+	ErrNotEnough       = errors.New("not enough players to start")
+)
+
+// Lobby is a private group of players who join by invite code and start the
+// game when the host decides to.
+type Lobby struct {
+	code string
+	// cfg is used for the session. Its capacity is the maximum number of
+	// players, the session is played by as many players as have joined.
+	cfg session.Config
+
+	host  string
+	users []*models.User
+}
+
+// Lobbies keeps the lobbies that have not started yet. Users in lobbies
+// claim their usernames in the registry just like the queue does.
+type Lobbies struct {
+	sessionCh chan session.Group
+	defaults  session.Config
+	registry  *models.Registry
+
+	mu      sync.Mutex
+	lobbies map[string]*Lobby
+}
+
+// NewLobbies creates the lobby registry. defaults are the settings of the
+// server that lobbies may override.
+func NewLobbies(sessionCh chan session.Group, defaults session.Config, registry *models.Registry) *Lobbies {
+	return &Lobbies{
+		sessionCh: sessionCh,
+		defaults:  defaults,
+		registry:  registry,
+		lobbies:   map[string]*Lobby{},
+	}
+}
+
+func (l *Lobbies) Defaults() session.Config {
+	return l.defaults
+}
+
+// Create opens a lobby with the host as its first player and returns its
+// invite code.
+func (l *Lobbies) Create(host *models.User, cfg session.Config) (string, error) {
+	if err := cfg.Validate(); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidSettings, err)
+	}
+
+	if err := l.registry.Claim(host.Username); err != nil {
+		return "", err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	code, err := l.newCode()
+	if err != nil {
+		l.registry.Release(host.Username)
+		return "", err
+	}
+
+	lobby := &Lobby{
+		code:  code,
+		cfg:   cfg,
+		host:  host.Username,
+		users: []*models.User{host},
+	}
+	l.lobbies[code] = lobby
+
+	log.Printf("lobby %s created by %s", code, host.Username)
+	l.notify(lobby, false)
+
+	return code, nil
+}
+
+func (l *Lobbies) Join(code string, user *models.User) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lobby, ok := l.lobbies[code]
+	if !ok {
+		return ErrUnknownLobby
+	}
+
+	if len(lobby.users) >= lobby.cfg.Capacity {
+		return ErrLobbyFull
+	}
+
+	if err := l.registry.Claim(user.Username); err != nil {
+		return err
+	}
+
+	lobby.users = append(lobby.users, user)
+	l.notify(lobby, false)
+
+	return nil
+}
+
+// Leave removes the user from the lobby. The lobby is closed if the host
+// leaves. The user is matched by pointer, since the username may have
+// rejoined from another client in the meantime.
+func (l *Lobbies) Leave(code string, user *models.User) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lobby, ok := l.lobbies[code]
+	if !ok {
+		return
+	}
+
+	removed := false
+	filtered := []*models.User{}
+	for _, u := range lobby.users {
+		if u == user {
+			u.Disconnect()
+			l.registry.Release(u.Username)
+			removed = true
+
+			continue
+		}
+
+		filtered = append(filtered, u)
+	}
+	lobby.users = filtered
+
+	if !removed {
+		return
+	}
+
+	if user.Username != lobby.host {
+		l.notify(lobby, false)
+		return
+	}
+
+	delete(l.lobbies, code)
+	l.notify(lobby, true)
+
+	for _, u := range lobby.users {
+		u.Disconnect()
+		l.registry.Release(u.Username)
+	}
+
+	log.Printf("lobby %s closed", code)
+}
+
+// Start hands the players of the lobby to the session manager.
+func (l *Lobbies) Start(code, username string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	lobby, ok := l.lobbies[code]
+	if !ok {
+		return ErrUnknownLobby
+	}
+
+	if lobby.host != username {
+		return ErrNotHost
+	}
+
+	cfg := lobby.cfg
+	cfg.Capacity = len(lobby.users)
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrNotEnough, err)
+	}
+
+	delete(l.lobbies, code)
+
+	l.sessionCh <- session.Group{
+		Users:  lobby.users,
+		Config: cfg,
+	}
+
+	log.Printf("lobby %s started with %d players", code, len(lobby.users))
+
+	return nil
+}
+
+func (l *Lobbies) notify(lobby *Lobby, closed bool) {
+	players := make([]string, 0, len(lobby.users))
+	for _, user := range lobby.users {
+		players = append(players, user.Username)
+	}
+
+	notification := &proto.Notifications{
+		Notification: &proto.Notifications_Lobby{
+			Lobby: &proto.LobbyNotification{
+				InviteCode: lobby.code,
+				Host:       lobby.host,
+				Players:    players,
+				Capacity:   int32(lobby.cfg.Capacity),
+				Closed:     closed,
+			},
+		},
+	}
+
+	for _, user := range lobby.users {
+		if err := user.Send(notification); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func (l *Lobbies) newCode() (string, error) {
+	for {
+		code := make([]byte, codeLength)
+		for i := range code {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(codeAlphabet))))
+			if err != nil {
+				return "", err
+			}
+
+			code[i] = codeAlphabet[n.Int64()]
+		}
+
+		if _, ok := l.lobbies[string(code)]; !ok {
+			return string(code), nil
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package models
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrUsernameTaken = errors.New("username is already in the queue or in a game")
+
+// Registry tracks the usernames that are waiting in the queue or playing in
+// an active session. Sessions key players by username, so a name may be
+// claimed only once at a time.
+type Registry struct {
+	mu        sync.Mutex
+	usernames map[string]struct{}
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		usernames: map[string]struct{}{},
+	}
+}
+
+func (r *Registry) Claim(username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.usernames[username]; ok {
+		return ErrUsernameTaken
+	}
+
+	r.usernames[username] = struct{}{}
+
+	return nil
+}
+
+func (r *Registry) Release(username string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.usernames, username)
+}
@@ -1,22 +1,119 @@
 package models
 
-import "github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/pkg/clock"
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+var ErrUserAway = errors.New("user is not connected")
+
+// NotificationStream is the server side of any stream that delivers
+// notifications to the player, e.g. the queue or a reconnect stream.
+type NotificationStream interface {
+	Send(*proto.Notifications) error
+}
 
 type User struct {
-	Username      string
-	Notifications proto.SOAMafia_ConnectQueueServer
+	Username string
 
+	mu               sync.Mutex
+	stream           NotificationStream
+	awaySince        time.Time
 	disconnectedChan chan struct{}
+
+	// clock measures how long the user is away.
+	clock clock.Clock
 }
 
-func NewUser(username string, s proto.SOAMafia_ConnectQueueServer) *User {
+func NewUser(username string, s NotificationStream) *User {
 	return &User{
 		Username:         username,
-		Notifications:    s,
+		stream:           s,
+		clock:            clock.Real(),
 		disconnectedChan: make(chan struct{}),
 	}
 }
 
+// Send delivers the notification to the attached stream. If the stream is
+// broken the user is marked as away until a new stream is attached.
+func (u *User) Send(n *proto.Notifications) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.stream == nil {
+		return ErrUserAway
+	}
+
+	if err := u.stream.Send(n); err != nil {
+		u.detach()
+		return err
+	}
+
+	return nil
+}
+
+// UseClock makes the user measure the time away with clk, e.g. the clock of
+// their session. A user who is already away counts as away from now on.
+func (u *User) UseClock(clk clock.Clock) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.clock = clk
+	if u.stream == nil {
+		u.awaySince = clk.Now()
+	}
+}
+
+// Attach replaces the notification stream, e.g. after a reconnect.
+func (u *User) Attach(s NotificationStream) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.stream = s
+	u.awaySince = time.Time{}
+}
+
+// Detach marks the user as away if s is still their current stream.
+func (u *User) Detach(s NotificationStream) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.stream == s {
+		u.detach()
+	}
+}
+
+func (u *User) detach() {
+	if u.stream != nil {
+		u.stream = nil
+		u.awaySince = u.clock.Now()
+	}
+}
+
+// AwayFor returns how long the user has been without a stream, zero if they
+// are connected.
+func (u *User) AwayFor() time.Duration {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.stream != nil {
+		return 0
+	}
+
+	return u.clock.Now().Sub(u.awaySince)
+}
+
+func (u *User) Connected() bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.stream != nil
+}
+
 func (u *User) Disconnect() {
 	select {
 	case u.disconnectedChan <- struct{}{}:
@@ -0,0 +1,129 @@
+package queue
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+)
+
+// Waiting is a player in the queue.
+type Waiting struct {
+	User  *models.User
+	Since time.Time
+}
+
+// Matchmaker decides which of the waiting players play together. Match is
+// called every time the queue changes and periodically, so that strategies
+// may relax their requirements as players wait.
+type Matchmaker interface {
+	// Match returns the players of the next session or nil if no session can
+	// be formed yet. waiting is ordered by the time players joined.
+	Match(waiting []Waiting, capacity int, now time.Time) []*models.User
+}
+
+// FIFO groups players in the order they joined.
+type FIFO struct{}
+
+func (FIFO) Match(waiting []Waiting, capacity int, now time.Time) []*models.User {
+	if len(waiting) < capacity {
+		return nil
+	}
+
+	users := make([]*models.User, 0, capacity)
+	for _, w := range waiting[:capacity] {
+		users = append(users, w.User)
+	}
+
+	return users
+}
+
+type Ratings interface {
+	Rating(username string) float64
+}
+
+// ByRating groups players with similar ratings. A group is formed when the
+// spread of its ratings fits the window, which widens by Growth rating points
+// per second the longest waiting player of the group has been in the queue.
+type ByRating struct {
+	Ratings Ratings
+	Window  float64
+	Growth  float64
+}
+
+func (m ByRating) Match(waiting []Waiting, capacity int, now time.Time) []*models.User {
+	if len(waiting) < capacity {
+		return nil
+	}
+
+	type rated struct {
+		Waiting
+		rating float64
+	}
+
+	players := make([]rated, 0, len(waiting))
+	for _, w := range waiting {
+		players = append(players, rated{
+			Waiting: w,
+			rating:  m.Ratings.Rating(w.User.Username),
+		})
+	}
+
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].rating < players[j].rating
+	})
+
+	// the best group is a run of neighbours in rating order with the smallest
+	// spread that fits its window
+	var best []rated
+	bestSpread := 0.0
+
+	for i := 0; i+capacity <= len(players); i++ {
+		group := players[i : i+capacity]
+		spread := group[capacity-1].rating - group[0].rating
+
+		longest := time.Duration(0)
+		for _, p := range group {
+			if wait := now.Sub(p.Since); wait > longest {
+				longest = wait
+			}
+		}
+
+		if spread > m.Window+m.Growth*longest.Seconds() {
+			continue
+		}
+
+		if best == nil || spread < bestSpread {
+			best = group
+			bestSpread = spread
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	users := make([]*models.User, 0, capacity)
+	for _, p := range best {
+		users = append(users, p.User)
+	}
+
+	return users
+}
+
+// MatchmakerByName returns the strategy selected by the -matchmaker flag.
+func MatchmakerByName(name string, ratings Ratings, window, growth float64) (Matchmaker, error) {
+	switch name {
+	case "fifo":
+		return FIFO{}, nil
+	case "rating":
+		return ByRating{
+			Ratings: ratings,
+			Window:  window,
+			Growth:  growth,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown matchmaker %q, supported: fifo, rating", name)
+	}
+}
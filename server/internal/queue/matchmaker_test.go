@@ -0,0 +1,85 @@
+package queue
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+)
+
+type ratings map[string]float64
+
+func (r ratings) Rating(username string) float64 {
+	return r[username]
+}
+
+func TestByRating(t *testing.T) {
+	now := time.Date(2023, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name     string
+		ratings  ratings
+		waited   map[string]time.Duration
+		capacity int
+		want     []string
+	}{
+		{
+			name:     "too few players",
+			ratings:  ratings{"alice": 1500},
+			capacity: 2,
+		},
+		{
+			name:     "spread above the window",
+			ratings:  ratings{"alice": 1500, "bob": 1600},
+			waited:   map[string]time.Duration{"alice": 4 * time.Second},
+			capacity: 2,
+		},
+		{
+			name:     "window widened by the wait",
+			ratings:  ratings{"alice": 1500, "bob": 1600},
+			waited:   map[string]time.Duration{"alice": 5 * time.Second},
+			capacity: 2,
+			want:     []string{"alice", "bob"},
+		},
+		{
+			name:     "smallest spread",
+			ratings:  ratings{"alice": 1000, "bob": 1400, "carol": 1450, "dave": 1480, "erin": 2000},
+			capacity: 2,
+			want:     []string{"carol", "dave"},
+		},
+		{
+			name:     "smallest spread that fits its window",
+			ratings:  ratings{"alice": 1000, "bob": 1080, "carol": 1400, "dave": 1500},
+			waited:   map[string]time.Duration{"carol": 5 * time.Second},
+			capacity: 2,
+			want:     []string{"carol", "dave"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			waiting := []Waiting{}
+			for _, username := range []string{"alice", "bob", "carol", "dave", "erin"} {
+				if _, ok := tc.ratings[username]; !ok {
+					continue
+				}
+
+				waiting = append(waiting, Waiting{
+					User:  models.NewUser(username, nil),
+					Since: now.Add(-tc.waited[username]),
+				})
+			}
+
+			m := ByRating{Ratings: tc.ratings, Window: 50, Growth: 10}
+			users := m.Match(waiting, tc.capacity, now)
+
+			var got []string
+			for _, user := range users {
+				got = append(got, user.Username)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
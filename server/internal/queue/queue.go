@@ -3,35 +3,69 @@ package queue
 import (
 	"log"
 	"sync"
+	"time"
 
 	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
 	"github.com/mcherdakov/soa-mafia/server/internal/models"
 	"github.com/mcherdakov/soa-mafia/server/internal/session"
 )
 
+// matchInterval is how often the queue retries matching, since matchmakers
+// may accept a group once its players have waited long enough.
+const matchInterval = time.Second
+
 type Queue struct {
-	users     []*models.User
-	sessionCh chan []*models.User
+	users      []Waiting
+	cfg        session.Config
+	matchmaker Matchmaker
+	sessionCh  chan session.Group
+	// registry is shared with the session manager, which releases the
+	// usernames once their session is over.
+	registry *models.Registry
 
 	mu sync.Mutex
 }
 
-func NewQueue(sessionCh chan []*models.User) *Queue {
+// NewQueue creates the public queue. Its sessions are played with cfg.
+func NewQueue(sessionCh chan session.Group, cfg session.Config, m Matchmaker, registry *models.Registry) *Queue {
 	return &Queue{
-		sessionCh: sessionCh,
+		cfg:        cfg,
+		matchmaker: m,
+		sessionCh:  sessionCh,
+		registry:   registry,
+	}
+}
+
+func (q *Queue) Run() {
+	ticker := time.NewTicker(matchInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		q.mu.Lock()
+		q.match(now)
+		q.mu.Unlock()
 	}
 }
 
-func (q *Queue) ConnectToQueue(user *models.User) {
+// ConnectToQueue adds the user to the queue. It fails with
+// models.ErrUsernameTaken if the username is already queued or playing.
+func (q *Queue) ConnectToQueue(user *models.User) error {
+	if err := q.registry.Claim(user.Username); err != nil {
+		return err
+	}
+
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	q.users = append(q.users, user)
-	if len(q.users) == session.SessionCapacity {
-		q.sessionCh <- q.users[:]
-		q.users = []*models.User{}
+	q.users = append(q.users, Waiting{
+		User:  user,
+		Since: time.Now(),
+	})
 
-		return
+	q.match(time.Now())
+
+	if !q.contains(user) {
+		return nil
 	}
 
 	q.sendNotification(&proto.Notifications{
@@ -42,24 +76,49 @@ func (q *Queue) ConnectToQueue(user *models.User) {
 			},
 		},
 	})
+
+	return nil
+}
+
+// DisconnectFromQueue removes the user whose queue stream has closed. The
+// username may already be queued again from another client, so the entry is
+// matched by the user itself.
+func (q *Queue) DisconnectFromQueue(user *models.User) {
+	q.remove(user.Username, func(w *models.User) bool {
+		return w == user
+	})
 }
 
-func (q *Queue) DisconnectFromQueue(username string) {
+// LeaveQueue removes the entry of the username, whichever client queued it.
+func (q *Queue) LeaveQueue(username string) {
+	q.remove(username, func(w *models.User) bool {
+		return w.Username == username
+	})
+}
+
+func (q *Queue) remove(username string, match func(*models.User) bool) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 
-	filtered := []*models.User{}
-	for _, queueUser := range q.users {
-		if queueUser.Username == username {
-			queueUser.Disconnect()
+	removed := false
+	filtered := []Waiting{}
+	for _, w := range q.users {
+		if match(w.User) {
+			w.User.Disconnect()
+			q.registry.Release(w.User.Username)
+			removed = true
 
 			continue
 		}
 
-		filtered = append(filtered, queueUser)
+		filtered = append(filtered, w)
 	}
 	q.users = filtered
 
+	if !removed {
+		return
+	}
+
 	q.sendNotification(&proto.Notifications{
 		Notification: &proto.Notifications_UserDisconnected{
 			UserDisconnected: &proto.UserDisconnectedNotification{
@@ -70,9 +129,47 @@ func (q *Queue) DisconnectFromQueue(username string) {
 	})
 }
 
+// match starts sessions for every group the matchmaker forms.
+func (q *Queue) match(now time.Time) {
+	for {
+		users := q.matchmaker.Match(q.users, q.cfg.Capacity, now)
+		if users == nil {
+			return
+		}
+
+		picked := make(map[*models.User]struct{}, len(users))
+		for _, user := range users {
+			picked[user] = struct{}{}
+		}
+
+		remaining := make([]Waiting, 0, len(q.users)-len(users))
+		for _, w := range q.users {
+			if _, ok := picked[w.User]; !ok {
+				remaining = append(remaining, w)
+			}
+		}
+		q.users = remaining
+
+		q.sessionCh <- session.Group{
+			Users:  users,
+			Config: q.cfg,
+		}
+	}
+}
+
+func (q *Queue) contains(user *models.User) bool {
+	for _, w := range q.users {
+		if w.User == user {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (q *Queue) sendNotification(notification *proto.Notifications) {
-	for _, user := range q.users {
-		err := user.Notifications.Send(notification)
+	for _, w := range q.users {
+		err := w.User.Send(notification)
 		if err != nil {
 			log.Println(err)
 		}
@@ -81,8 +178,8 @@ func (q *Queue) sendNotification(notification *proto.Notifications) {
 
 func (q *Queue) usernames() []string {
 	usernames := make([]string, 0, len(q.users))
-	for _, user := range q.users {
-		usernames = append(usernames, user.Username)
+	for _, w := range q.users {
+		usernames = append(usernames, w.User.Username)
 	}
 
 	return usernames
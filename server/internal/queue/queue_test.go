@@ -0,0 +1,53 @@
+package queue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+)
+
+type stream struct{}
+
+func (stream) Send(*proto.Notifications) error {
+	return nil
+}
+
+func TestStaleStreamKeepsNewEntry(t *testing.T) {
+	registry := models.NewRegistry()
+	sessionCh := make(chan session.Group, 1)
+
+	cfg := session.DefaultConfig()
+	cfg.Capacity = 2
+	q := NewQueue(sessionCh, cfg, FIFO{}, registry)
+
+	old := models.NewUser("alice", stream{})
+	for _, user := range []*models.User{old, models.NewUser("bob", stream{})} {
+		if err := q.ConnectToQueue(user); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the game is over, alice queues again from another client while the old
+	// queue stream is still open
+	<-sessionCh
+	registry.Release("alice")
+	registry.Release("bob")
+
+	fresh := models.NewUser("alice", stream{})
+	if err := q.ConnectToQueue(fresh); err != nil {
+		t.Fatal(err)
+	}
+
+	q.DisconnectFromQueue(old)
+
+	if !q.contains(fresh) {
+		t.Fatal("the closed stream removed the new queue entry")
+	}
+
+	if err := registry.Claim("alice"); !errors.Is(err, models.ErrUsernameTaken) {
+		t.Fatalf("the claim of the new entry was released: %v", err)
+	}
+}
@@ -0,0 +1,119 @@
+package rating
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"sync"
+
+	"github.com/mcherdakov/soa-mafia/pkg/fileutil"
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+const (
+	// InitialRating is given to players who have not finished a game yet.
+	InitialRating = 1500
+	// k limits how much a single game can change a rating.
+	k = 32
+)
+
+// Elo keeps per-player Elo ratings in a JSON file. Mafia and town are treated
+// as two teams, each rated by the average rating of its members.
+type Elo struct {
+	path string
+
+	mu      sync.Mutex
+	ratings map[string]float64
+}
+
+func NewElo(path string) (*Elo, error) {
+	e := &Elo{
+		path:    path,
+		ratings: map[string]float64{},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return e, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &e.ratings); err != nil {
+		return nil, fmt.Errorf("parse ratings %s: %w", path, err)
+	}
+
+	return e, nil
+}
+
+func (e *Elo) Rating(username string) float64 {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.rating(username)
+}
+
+func (e *Elo) rating(username string) float64 {
+	if r, ok := e.ratings[username]; ok {
+		return r
+	}
+
+	return InitialRating
+}
+
+// Update rates a finished game. roles are the roles of every player of the
+// session, winner is the team that won.
+func (e *Elo) Update(roles map[string]proto.Role, winner proto.Role) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var winners, losers []string
+	for username, role := range roles {
+		if team(role) == team(winner) {
+			winners = append(winners, username)
+		} else {
+			losers = append(losers, username)
+		}
+	}
+
+	if len(winners) == 0 || len(losers) == 0 {
+		return nil
+	}
+
+	expected := 1 / (1 + math.Pow(10, (e.average(losers)-e.average(winners))/400))
+	delta := k * (1 - expected)
+
+	for _, username := range winners {
+		e.ratings[username] = e.rating(username) + delta
+	}
+	for _, username := range losers {
+		e.ratings[username] = e.rating(username) - delta
+	}
+
+	return e.save()
+}
+
+func (e *Elo) average(usernames []string) float64 {
+	sum := 0.0
+	for _, username := range usernames {
+		sum += e.rating(username)
+	}
+
+	return sum / float64(len(usernames))
+}
+
+func (e *Elo) save() error {
+	return fileutil.WriteJSONAtomic(e.path, e.ratings)
+}
+
+// team maps every non-mafia role to the town.
+func team(role proto.Role) proto.Role {
+	if role == proto.Role_MAFIA {
+		return proto.Role_MAFIA
+	}
+
+	return proto.Role_CIVILIAN
+}
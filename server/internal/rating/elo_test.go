@@ -0,0 +1,113 @@
+package rating
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+func TestUpdate(t *testing.T) {
+	roles := map[string]proto.Role{
+		"alice": proto.Role_MAFIA,
+		"bob":   proto.Role_DETECITVE,
+		"carol": proto.Role_DOCTOR,
+		"dave":  proto.Role_CIVILIAN,
+	}
+	town := []string{"bob", "carol", "dave"}
+	mafia := []string{"alice"}
+
+	for _, tc := range []struct {
+		name    string
+		ratings map[string]float64
+		winner  proto.Role
+		// delta is how much every winner gains and every loser loses
+		delta float64
+	}{
+		{
+			name:   "even teams",
+			winner: proto.Role_CIVILIAN,
+			delta:  16,
+		},
+		{
+			name:    "favourite wins",
+			ratings: map[string]float64{"alice": 1300},
+			winner:  proto.Role_CIVILIAN,
+			delta:   7.688,
+		},
+		{
+			name:    "underdog wins",
+			ratings: map[string]float64{"alice": 1300},
+			winner:  proto.Role_MAFIA,
+			delta:   24.312,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "ratings.json")
+
+			// without a file everybody starts at the initial rating
+			if tc.ratings != nil {
+				data, err := json.Marshal(tc.ratings)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(path, data, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			e, err := NewElo(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			before := map[string]float64{}
+			for username := range roles {
+				before[username] = e.Rating(username)
+			}
+
+			if err := e.Update(roles, tc.winner); err != nil {
+				t.Fatal(err)
+			}
+
+			// the teams are rated by their average, what one team gains the
+			// other one loses
+			change := func(usernames []string) float64 {
+				sum := 0.0
+				for _, username := range usernames {
+					sum += e.Rating(username) - before[username]
+				}
+
+				return sum / float64(len(usernames))
+			}
+
+			winners, losers := town, mafia
+			if tc.winner == proto.Role_MAFIA {
+				winners, losers = mafia, town
+			}
+
+			if got := change(winners); math.Abs(got-tc.delta) > 0.001 {
+				t.Fatalf("winners gained %.3f, want %.3f", got, tc.delta)
+			}
+
+			if got := change(winners) + change(losers); math.Abs(got) > 1e-9 {
+				t.Fatalf("team changes do not cancel out: %v", got)
+			}
+
+			reopened, err := NewElo(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for username := range roles {
+				if reopened.Rating(username) != e.Rating(username) {
+					t.Fatalf("%s: saved %v, want %v", username, reopened.Rating(username), e.Rating(username))
+				}
+			}
+		})
+	}
+}
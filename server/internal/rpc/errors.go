@@ -0,0 +1,76 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/auth"
+	"github.com/mcherdakov/soa-mafia/server/internal/lobby"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// commandStatus converts a command validation or reconnect error into a gRPC
+// status error.
+func commandStatus(err error) error {
+	switch {
+	case errors.Is(err, session.ErrEmptyCommand),
+		errors.Is(err, session.ErrUnknownTarget),
+		errors.Is(err, session.ErrNotCandidate):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, session.ErrNotMember),
+		errors.Is(err, session.ErrWrongRole),
+		errors.Is(err, session.ErrInvalidToken):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, session.ErrSessionClosed),
+		errors.Is(err, session.ErrPlayerDead),
+		errors.Is(err, session.ErrWrongPhase),
+		errors.Is(err, session.ErrPhaseOver),
+		errors.Is(err, session.ErrAlreadyActed),
+		errors.Is(err, session.ErrDeadTarget),
+		errors.Is(err, session.ErrRepeatHeal):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// authStatus converts a registration or login error into a gRPC status error.
+func authStatus(err error) error {
+	switch {
+	case errors.Is(err, auth.ErrInvalidCredentials):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, auth.ErrUserExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, auth.ErrUnknownUser):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, auth.ErrWrongPassword):
+		return status.Error(codes.Unauthenticated, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// lobbyStatus converts a lobby error into a gRPC status error.
+func lobbyStatus(err error) error {
+	switch {
+	case errors.Is(err, lobby.ErrInvalidSettings):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, lobby.ErrUnknownLobby):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, models.ErrUsernameTaken):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, lobby.ErrNotHost):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, lobby.ErrLobbyFull),
+		errors.Is(err, lobby.ErrNotEnough):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
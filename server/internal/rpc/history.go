@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/auth"
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/history"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultGamesLimit = 10
+
+func (s *SOAMafiaServer) ListGames(ctx context.Context, in *proto.ListGamesIn) (*proto.ListGamesOut, error) {
+	username := in.Username
+	if username == "" {
+		username = auth.Username(ctx)
+	}
+
+	limit := int(in.Limit)
+	if limit <= 0 {
+		limit = defaultGamesLimit
+	}
+
+	games, err := s.history.List(username, limit)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &proto.ListGamesOut{Games: games}, nil
+}
+
+func (s *SOAMafiaServer) GetGame(ctx context.Context, in *proto.GetGameIn) (*proto.GameRecord, error) {
+	record, err := s.history.Get(in.GameId)
+	if errors.Is(err, history.ErrUnknownGame) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return record, nil
+}
@@ -0,0 +1,102 @@
+package rpc
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/auth"
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+	"github.com/mcherdakov/soa-mafia/server/internal/rules"
+	"github.com/mcherdakov/soa-mafia/server/internal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *SOAMafiaServer) CreateLobby(in *proto.CreateLobbyIn, srv proto.SOAMafia_CreateLobbyServer) error {
+	cfg, err := lobbyConfig(s.lobbies.Defaults(), in.Settings)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	user := models.NewUser(auth.Username(srv.Context()), srv)
+
+	code, err := s.lobbies.Create(user, cfg)
+	if err != nil {
+		return lobbyStatus(err)
+	}
+
+	s.waitInLobby(srv.Context(), code, user, srv)
+
+	return nil
+}
+
+func (s *SOAMafiaServer) JoinLobby(in *proto.JoinLobbyIn, srv proto.SOAMafia_JoinLobbyServer) error {
+	user := models.NewUser(auth.Username(srv.Context()), srv)
+
+	if err := s.lobbies.Join(in.InviteCode, user); err != nil {
+		return lobbyStatus(err)
+	}
+
+	log.Printf("user %s joined lobby %s", user.Username, in.InviteCode)
+
+	s.waitInLobby(srv.Context(), in.InviteCode, user, srv)
+
+	return nil
+}
+
+func (s *SOAMafiaServer) StartLobby(ctx context.Context, in *proto.StartLobbyIn) (*proto.StartLobbyOut, error) {
+	if err := s.lobbies.Start(in.InviteCode, auth.Username(ctx)); err != nil {
+		return nil, lobbyStatus(err)
+	}
+
+	return &proto.StartLobbyOut{Ok: true}, nil
+}
+
+// waitInLobby keeps the notification stream open until the player leaves or
+// the lobby is closed. Once the game starts the stream delivers its
+// notifications, the same way as the queue stream does.
+func (s *SOAMafiaServer) waitInLobby(ctx context.Context, code string, user *models.User, srv models.NotificationStream) {
+	select {
+	case <-ctx.Done():
+		s.lobbies.Leave(code, user)
+		user.Detach(srv)
+	case <-user.Disconnected():
+	}
+
+	log.Printf("user %s left lobby %s", user.Username, code)
+}
+
+// lobbyConfig applies the lobby settings on top of the server defaults.
+func lobbyConfig(defaults session.Config, settings *proto.LobbySettings) (session.Config, error) {
+	cfg := defaults
+	if settings == nil {
+		return cfg, nil
+	}
+
+	if settings.Capacity != 0 {
+		cfg.Capacity = int(settings.Capacity)
+	}
+
+	if settings.Ruleset != "" {
+		ruleset, err := rules.ByName(settings.Ruleset)
+		if err != nil {
+			return session.Config{}, err
+		}
+
+		cfg.Ruleset = ruleset
+	}
+
+	if settings.DiscussionTimeoutMs != 0 {
+		cfg.DiscussionTimeout = time.Duration(settings.DiscussionTimeoutMs) * time.Millisecond
+	}
+	if settings.VoteTimeoutMs != 0 {
+		cfg.VoteTimeout = time.Duration(settings.VoteTimeoutMs) * time.Millisecond
+	}
+	if settings.NightTimeoutMs != 0 {
+		cfg.NightTimeout = time.Duration(settings.NightTimeoutMs) * time.Millisecond
+	}
+
+	return cfg, nil
+}
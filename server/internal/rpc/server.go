@@ -2,38 +2,108 @@ package rpc
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log"
 
+	"github.com/mcherdakov/soa-mafia/server/internal/auth"
 	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/history"
+	"github.com/mcherdakov/soa-mafia/server/internal/lobby"
 	"github.com/mcherdakov/soa-mafia/server/internal/models"
 	"github.com/mcherdakov/soa-mafia/server/internal/queue"
 	"github.com/mcherdakov/soa-mafia/server/internal/session"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// PublicMethods can be called without logging in.
+var PublicMethods = []string{
+	"/SOAMafia/Register",
+	"/SOAMafia/Login",
+}
+
 type SOAMafiaServer struct {
 	proto.UnimplementedSOAMafiaServer
 
 	queue          *queue.Queue
+	lobbies        *lobby.Lobbies
 	sessionManager *session.SessionManager
+	history        *history.Store
+	users          *auth.FileStore
+	tokens         *auth.Tokens
+	// spectators may watch any session in omniscient mode.
+	spectators map[string]struct{}
 }
 
-func NewSOAMafiaServer(q *queue.Queue, sm *session.SessionManager) *SOAMafiaServer {
+func NewSOAMafiaServer(
+	q *queue.Queue,
+	lobbies *lobby.Lobbies,
+	sm *session.SessionManager,
+	games *history.Store,
+	users *auth.FileStore,
+	tokens *auth.Tokens,
+	spectators []string,
+) *SOAMafiaServer {
+	approved := make(map[string]struct{}, len(spectators))
+	for _, username := range spectators {
+		approved[username] = struct{}{}
+	}
+
 	return &SOAMafiaServer{
 		queue:          q,
+		lobbies:        lobbies,
 		sessionManager: sm,
+		history:        games,
+		users:          users,
+		tokens:         tokens,
+		spectators:     approved,
+	}
+}
+
+func (s *SOAMafiaServer) Register(ctx context.Context, in *proto.RegisterIn) (*proto.LoginOut, error) {
+	if err := s.users.Register(in.Username, in.Password); err != nil {
+		return nil, authStatus(err)
+	}
+
+	log.Printf("user %s registered", in.Username)
+
+	return s.issueToken(in.Username)
+}
+
+func (s *SOAMafiaServer) Login(ctx context.Context, in *proto.LoginIn) (*proto.LoginOut, error) {
+	if err := s.users.Check(in.Username, in.Password); err != nil {
+		return nil, authStatus(err)
 	}
+
+	return s.issueToken(in.Username)
+}
+
+func (s *SOAMafiaServer) issueToken(username string) (*proto.LoginOut, error) {
+	token, err := s.tokens.Issue(username)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &proto.LoginOut{Token: token}, nil
 }
 
 func (s *SOAMafiaServer) ConnectQueue(in *proto.ConnectQueueIn, srv proto.SOAMafia_ConnectQueueServer) error {
-	user := models.NewUser(in.Username, srv)
+	user := models.NewUser(auth.Username(srv.Context()), srv)
+
+	if err := s.queue.ConnectToQueue(user); err != nil {
+		if errors.Is(err, models.ErrUsernameTaken) {
+			return status.Error(codes.AlreadyExists, err.Error())
+		}
+
+		return status.Error(codes.Internal, err.Error())
+	}
 
-	s.queue.ConnectToQueue(user)
 	log.Printf("user %s connected", user.Username)
 
 	select {
 	case <-srv.Context().Done():
-		s.queue.DisconnectFromQueue(user.Username)
+		s.queue.DisconnectFromQueue(user)
+		user.Detach(srv)
 	case <-user.Disconnected():
 	}
 
@@ -43,7 +113,7 @@ func (s *SOAMafiaServer) ConnectQueue(in *proto.ConnectQueueIn, srv proto.SOAMaf
 }
 
 func (s *SOAMafiaServer) DisconnectQueue(ctx context.Context, in *proto.DisconnectQueueIn) (*proto.DisconnectQueueOut, error) {
-	s.queue.DisconnectFromQueue(in.Username)
+	s.queue.LeaveQueue(auth.Username(ctx))
 
 	return &proto.DisconnectQueueOut{Ok: true}, nil
 }
@@ -51,14 +121,91 @@ func (s *SOAMafiaServer) DisconnectQueue(ctx context.Context, in *proto.Disconne
 func (s *SOAMafiaServer) SendCommand(ctx context.Context, in *proto.SendCommandIn) (*proto.SendCommandOut, error) {
 	curSession := s.sessionManager.SessionByID(in.SessionId)
 	if curSession == nil {
-		return nil, fmt.Errorf("invalid session id")
+		return nil, status.Error(codes.NotFound, "invalid session id")
 	}
 
-	c := curSession.CmdChan()
-	c <- session.Command{
+	err := curSession.Submit(ctx, session.Command{
 		Cmd:      in.Command,
-		Username: in.Username,
+		Username: auth.Username(ctx),
+	})
+	if err != nil {
+		return nil, commandStatus(err)
 	}
 
 	return &proto.SendCommandOut{Ok: true}, nil
 }
+
+func (s *SOAMafiaServer) Reconnect(in *proto.ReconnectIn, srv proto.SOAMafia_ReconnectServer) error {
+	curSession := s.sessionManager.SessionByID(in.SessionId)
+	if curSession == nil {
+		return status.Error(codes.NotFound, "invalid session id")
+	}
+
+	user, err := curSession.Reconnect(auth.Username(srv.Context()), in.ReconnectToken, srv)
+	if err != nil {
+		return commandStatus(err)
+	}
+
+	log.Printf("user %s reconnected to session %d", user.Username, in.SessionId)
+
+	select {
+	case <-srv.Context().Done():
+		user.Detach(srv)
+	case <-curSession.Done():
+	}
+
+	log.Printf("user %s disconnected", user.Username)
+
+	return nil
+}
+
+func (s *SOAMafiaServer) WatchSession(in *proto.WatchSessionIn, srv proto.SOAMafia_WatchSessionServer) error {
+	curSession := s.sessionManager.SessionByID(in.SessionId)
+	if curSession == nil {
+		return status.Error(codes.NotFound, "invalid session id")
+	}
+
+	username := auth.Username(srv.Context())
+
+	if _, approved := s.spectators[username]; in.Omniscient && !approved && !curSession.IsDead(username) {
+		return status.Error(codes.PermissionDenied, "omniscient mode is only for eliminated players and approved spectators")
+	}
+
+	spectator, err := curSession.Watch(username, in.Omniscient, srv)
+	if err != nil {
+		return commandStatus(err)
+	}
+
+	log.Printf("user %s watches session %d", username, in.SessionId)
+
+	select {
+	case <-srv.Context().Done():
+		curSession.Unwatch(spectator)
+	case <-curSession.Done():
+	}
+
+	return nil
+}
+
+func (s *SOAMafiaServer) GetMembership(ctx context.Context, in *proto.MembershipIn) (*proto.Membership, error) {
+	curSession := s.sessionManager.SessionByID(in.SessionId)
+	if curSession == nil {
+		return nil, status.Error(codes.NotFound, "invalid session id")
+	}
+
+	username := auth.Username(ctx)
+
+	member, ok := curSession.Member(username)
+	if !ok {
+		return &proto.Membership{Username: username}, nil
+	}
+
+	return &proto.Membership{
+		Username: username,
+		Member:   true,
+		Alive:    member.Alive,
+		Role:     member.Role,
+		Night:    member.Night,
+		Finished: member.Finished,
+	}, nil
+}
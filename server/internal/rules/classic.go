@@ -0,0 +1,82 @@
+package rules
+
+import "github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+
+func init() {
+	Register(Classic{})
+	Register(Open{})
+}
+
+// Classic is the default ruleset. The first day and night are for getting to
+// know each other, roles stay hidden until the end of the game and the mafia
+// wins once it is not outnumbered.
+type Classic struct{}
+
+func (Classic) Name() string {
+	return "classic"
+}
+
+func (Classic) DayPhase(day int64) Phase {
+	if day == 1 {
+		return PhasePass
+	}
+
+	return PhaseVote
+}
+
+func (Classic) NightPhase(day int64) Phase {
+	if day == 1 {
+		return PhasePass
+	}
+
+	return PhaseNight
+}
+
+func (Classic) NightOrder() []NightAction {
+	return []NightAction{ActionHeal, ActionKill, ActionCheck}
+}
+
+func (Classic) RevealRoleOnDeath() bool {
+	return false
+}
+
+func (Classic) Winner(alive map[string]proto.Role) (proto.Role, bool) {
+	mafiaCount := 0
+	civilianCount := 0
+
+	for _, role := range alive {
+		if role == proto.Role_MAFIA {
+			mafiaCount++
+		} else {
+			civilianCount++
+		}
+	}
+
+	if mafiaCount == 0 {
+		return proto.Role_CIVILIAN, true
+	}
+
+	if mafiaCount >= civilianCount {
+		return proto.Role_MAFIA, true
+	}
+
+	return 0, false
+}
+
+// Open votes from the very first day and announces the role of every player
+// who leaves the game.
+type Open struct {
+	Classic
+}
+
+func (Open) Name() string {
+	return "open"
+}
+
+func (Open) DayPhase(int64) Phase {
+	return PhaseVote
+}
+
+func (Open) RevealRoleOnDeath() bool {
+	return true
+}
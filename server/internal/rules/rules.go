@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+type Phase int
+
+const (
+	// PhasePass is a phase without actions, every player just passes.
+	PhasePass Phase = iota
+	PhaseVote
+	PhaseNight
+)
+
+type NightAction int
+
+const (
+	ActionHeal NightAction = iota
+	ActionKill
+	ActionCheck
+)
+
+// Ruleset describes a game variant. The session drives the game loop and
+// asks the ruleset about everything that differs between variants.
+type Ruleset interface {
+	Name() string
+	// DayPhase returns what happens during the given day, PhasePass or
+	// PhaseVote.
+	DayPhase(day int64) Phase
+	// NightPhase returns what happens during the given night, PhasePass or
+	// PhaseNight.
+	NightPhase(day int64) Phase
+	// NightOrder returns the order in which night actions are resolved. A
+	// heal only saves from kills resolved after it and a check is only
+	// reported if the detective is still alive when it is resolved.
+	NightOrder() []NightAction
+	// RevealRoleOnDeath tells whether the role of a killed or voted out
+	// player is announced.
+	RevealRoleOnDeath() bool
+	// Winner returns the winning side given the roles of alive players.
+	Winner(alive map[string]proto.Role) (proto.Role, bool)
+}
+
+var registry = map[string]Ruleset{}
+
+// Default returns the ruleset that describes the original game.
+func Default() Ruleset {
+	return Classic{}
+}
+
+func Register(r Ruleset) {
+	registry[r.Name()] = r
+}
+
+func ByName(name string) (Ruleset, error) {
+	r, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown ruleset %q, available: %v", name, Names())
+	}
+
+	return r, nil
+}
+
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
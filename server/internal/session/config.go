@@ -0,0 +1,73 @@
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/rules"
+)
+
+const DefaultCapacity = 4
+
+type Config struct {
+	Capacity int
+
+	// DiscussionTimeout limits the day 1 and night 1 phases, where players
+	// only get to know each other and pass.
+	DiscussionTimeout time.Duration
+	// VoteTimeout limits the day vote. Players who did not vote abstain.
+	VoteTimeout time.Duration
+	// NightTimeout limits the night. Missing kills and checks are skipped.
+	NightTimeout time.Duration
+
+	TieRule TieRule
+	// ForbidRepeatHeal stops the doctor from healing the same player two
+	// nights in a row.
+	ForbidRepeatHeal bool
+	KillRule         KillRule
+	// PublishDetectiveFindings announces to everyone when the detective finds
+	// mafia. The detective always learns the result privately.
+	PublishDetectiveFindings bool
+
+	Ruleset rules.Ruleset
+
+	// ReconnectGrace is how long phases keep waiting for a disconnected
+	// player before treating them as if they missed the deadline.
+	ReconnectGrace time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Capacity:          DefaultCapacity,
+		DiscussionTimeout: time.Minute,
+		VoteTimeout:       time.Minute,
+		NightTimeout:      time.Minute,
+		TieRule:           TieRandom,
+		Ruleset:           rules.Default(),
+		ReconnectGrace:    30 * time.Second,
+	}
+}
+
+func (c Config) Validate() error {
+	if _, ok := distributionFor(c.Capacity); !ok {
+		return fmt.Errorf(
+			"unsupported session capacity %d, supported: %v",
+			c.Capacity,
+			SupportedCapacities(),
+		)
+	}
+
+	if c.Ruleset == nil {
+		return fmt.Errorf("ruleset is not set")
+	}
+
+	if c.DiscussionTimeout <= 0 || c.VoteTimeout <= 0 || c.NightTimeout <= 0 {
+		return fmt.Errorf("phase timeouts must be positive")
+	}
+
+	if c.ReconnectGrace < 0 {
+		return fmt.Errorf("reconnect grace must not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package session
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/rules"
+)
+
+type EventType string
+
+const (
+	EventSessionCreated   EventType = "session_created"
+	EventRolesAssigned    EventType = "roles_assigned"
+	EventPhaseChanged     EventType = "phase_changed"
+	EventCommandReceived  EventType = "command_received"
+	EventPhaseClosed      EventType = "phase_closed"
+	EventPlayerEliminated EventType = "player_eliminated"
+	EventGameEnded        EventType = "game_ended"
+)
+
+// Event is an entry of the append-only session log. The log holds the seed
+// of the session and every command in the order the game loop consumed it,
+// which is enough to replay the game, see Replay.
+type Event struct {
+	Seq  int       `json:"seq"`
+	Time time.Time `json:"time"`
+	Type EventType `json:"type"`
+	Day  int64     `json:"day,omitempty"`
+
+	// set for EventSessionCreated
+	SessionID int64     `json:"session_id,omitempty"`
+	Seed      int64     `json:"seed,omitempty"`
+	Players   []string  `json:"players,omitempty"`
+	Settings  *Settings `json:"settings,omitempty"`
+
+	// set for EventRolesAssigned, in the order of Players
+	Roles []proto.Role `json:"roles,omitempty"`
+
+	// set for EventPhaseChanged, Candidates only for a runoff
+	Phase      string   `json:"phase,omitempty"`
+	Candidates []string `json:"candidates,omitempty"`
+
+	// set for EventCommandReceived and EventPlayerEliminated
+	Username string         `json:"username,omitempty"`
+	Command  *CommandRecord `json:"command,omitempty"`
+	Reason   string         `json:"reason,omitempty"`
+
+	// set for EventGameEnded, Reason is set instead if the game was aborted
+	Winner *proto.Role `json:"winner,omitempty"`
+}
+
+// Settings are the parts of Config that affect the outcome of the game.
+type Settings struct {
+	TieRule                  string `json:"tie_rule"`
+	KillRule                 string `json:"kill_rule"`
+	Ruleset                  string `json:"ruleset"`
+	ForbidRepeatHeal         bool   `json:"forbid_repeat_heal"`
+	PublishDetectiveFindings bool   `json:"publish_detective_findings"`
+}
+
+func newSettings(cfg Config) *Settings {
+	return &Settings{
+		TieRule:                  cfg.TieRule.String(),
+		KillRule:                 cfg.KillRule.String(),
+		Ruleset:                  cfg.Ruleset.Name(),
+		ForbidRepeatHeal:         cfg.ForbidRepeatHeal,
+		PublishDetectiveFindings: cfg.PublishDetectiveFindings,
+	}
+}
+
+// config restores the session config. Timeouts do not affect a replay and
+// keep their defaults.
+func (s *Settings) config(capacity int) (Config, error) {
+	cfg := DefaultConfig()
+	cfg.Capacity = capacity
+	cfg.ForbidRepeatHeal = s.ForbidRepeatHeal
+	cfg.PublishDetectiveFindings = s.PublishDetectiveFindings
+
+	if err := cfg.TieRule.Set(s.TieRule); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.KillRule.Set(s.KillRule); err != nil {
+		return Config{}, err
+	}
+
+	ruleset, err := rules.ByName(s.Ruleset)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Ruleset = ruleset
+
+	return cfg, nil
+}
+
+// CommandRecord is a readable form of a command in the log.
+type CommandRecord struct {
+	Kind    string `json:"kind"`
+	Target  string `json:"target,omitempty"`
+	Abstain bool   `json:"abstain,omitempty"`
+}
+
+func newCommandRecord(cmd *proto.Commands) *CommandRecord {
+	switch c := cmd.GetCommand().(type) {
+	case *proto.Commands_PassCommand:
+		return &CommandRecord{Kind: "pass"}
+	case *proto.Commands_VoteCommand:
+		return &CommandRecord{Kind: "vote", Target: c.VoteCommand.Username, Abstain: c.VoteCommand.Abstain}
+	case *proto.Commands_KillCommand:
+		return &CommandRecord{Kind: "kill", Target: c.KillCommand.Username}
+	case *proto.Commands_CheckCommand:
+		return &CommandRecord{Kind: "check", Target: c.CheckCommand.Username}
+	case *proto.Commands_HealCommand:
+		return &CommandRecord{Kind: "heal", Target: c.HealCommand.Username}
+	default:
+		return &CommandRecord{Kind: "unknown"}
+	}
+}
+
+func (r *CommandRecord) proto() (*proto.Commands, error) {
+	switch r.Kind {
+	case "pass":
+		return &proto.Commands{Command: &proto.Commands_PassCommand{
+			PassCommand: &proto.PassCommand{},
+		}}, nil
+	case "vote":
+		return &proto.Commands{Command: &proto.Commands_VoteCommand{
+			VoteCommand: &proto.VoteCommand{Username: r.Target, Abstain: r.Abstain},
+		}}, nil
+	case "kill":
+		return &proto.Commands{Command: &proto.Commands_KillCommand{
+			KillCommand: &proto.KillCommand{Username: r.Target},
+		}}, nil
+	case "check":
+		return &proto.Commands{Command: &proto.Commands_CheckCommand{
+			CheckCommand: &proto.CheckCommand{Username: r.Target},
+		}}, nil
+	case "heal":
+		return &proto.Commands{Command: &proto.Commands_HealCommand{
+			HealCommand: &proto.HealCommand{Username: r.Target},
+		}}, nil
+	default:
+		return nil, fmt.Errorf("unknown command kind %q", r.Kind)
+	}
+}
+
+// emit appends the event to the log. It is only called by the goroutine
+// running the session.
+func (s *Session) emit(e Event) {
+	e.Seq = len(s.events) + 1
+	e.Time = s.clock.Now()
+	e.Day = s.day
+
+	s.events = append(s.events, e)
+
+	if s.onEvent != nil {
+		s.onEvent(e)
+	}
+}
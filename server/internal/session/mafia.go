@@ -0,0 +1,174 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+type KillRule int
+
+const (
+	// KillMajority kills the target picked by more than half of the alive
+	// mafia.
+	KillMajority KillRule = iota
+	// KillUnanimous kills only if every alive mafia picked the same target.
+	KillUnanimous
+	// KillDon lets the don have the final say. The don is the first mafia in
+	// the session, when they die the next alive mafia takes over.
+	KillDon
+)
+
+var killRuleNames = map[string]KillRule{
+	"majority":  KillMajority,
+	"unanimous": KillUnanimous,
+	"don":       KillDon,
+}
+
+func (r KillRule) String() string {
+	for name, rule := range killRuleNames {
+		if rule == r {
+			return name
+		}
+	}
+
+	return "unknown"
+}
+
+// Set implements flag.Value.
+func (r *KillRule) Set(name string) error {
+	rule, ok := killRuleNames[name]
+	if !ok {
+		return fmt.Errorf("unknown kill rule %q, expected one of: majority, unanimous, don", name)
+	}
+
+	*r = rule
+
+	return nil
+}
+
+// mafia returns the mafia members in session order.
+func (s *Session) mafia() []string {
+	res := []string{}
+	for _, user := range s.users {
+		if s.roles[user.Username] == proto.Role_MAFIA {
+			res = append(res, user.Username)
+		}
+	}
+
+	return res
+}
+
+func (s *Session) aliveMafia() []string {
+	res := []string{}
+	for _, username := range s.mafia() {
+		if _, ok := s.alive[username]; ok {
+			res = append(res, username)
+		}
+	}
+
+	return res
+}
+
+// don returns the alive mafia member who has the final say under KillDon.
+func (s *Session) don() *string {
+	mafia := s.aliveMafia()
+	if len(mafia) == 0 {
+		return nil
+	}
+
+	return &mafia[0]
+}
+
+// resolveKill picks the night victim from the mafia picks according to the
+// kill rule. It returns nil if the mafia did not agree.
+func (s *Session) resolveKill(picks map[string]string) *string {
+	mafia := s.aliveMafia()
+
+	switch s.cfg.KillRule {
+	case KillDon:
+		don := s.don()
+		if don == nil {
+			return nil
+		}
+
+		if target, ok := picks[*don]; ok {
+			return &target
+		}
+	case KillUnanimous:
+		if len(picks) != len(mafia) || len(mafia) == 0 {
+			return nil
+		}
+
+		target := picks[mafia[0]]
+		for _, username := range mafia {
+			if picks[username] != target {
+				return nil
+			}
+		}
+
+		return &target
+	case KillMajority:
+		count := map[string]int{}
+		for _, target := range picks {
+			count[target]++
+
+			if count[target]*2 > len(mafia) {
+				return &target
+			}
+		}
+	}
+
+	return nil
+}
+
+// killSettled reports whether the night can end without waiting for the
+// mafia to change their picks.
+func (s *Session) killSettled(picks map[string]string) bool {
+	if s.cfg.KillRule == KillDon {
+		don := s.don()
+		if don == nil {
+			return true
+		}
+
+		_, ok := picks[*don]
+
+		return ok
+	}
+
+	return len(picks) == len(s.aliveMafia()) && s.resolveKill(picks) != nil
+}
+
+// sendMafiaPicks shows the current picks to the alive mafia members only.
+func (s *Session) sendMafiaPicks(picks map[string]string) {
+	mafia := s.aliveMafia()
+	if len(mafia) < 2 {
+		return
+	}
+
+	votes := make([]*proto.Vote, 0, len(picks))
+	for voter, target := range picks {
+		votes = append(votes, &proto.Vote{
+			Voter:    voter,
+			Username: ptr(target),
+		})
+	}
+
+	sort.Slice(votes, func(i, j int) bool {
+		return votes[i].Voter < votes[j].Voter
+	})
+
+	notification := &proto.Notifications{
+		Notification: &proto.Notifications_MafiaPicks{
+			MafiaPicks: &proto.MafiaPicksNotification{
+				Picks:   votes,
+				Settled: s.killSettled(picks),
+			},
+		},
+	}
+
+	for _, username := range mafia {
+		s.send(s.alive[username], notification)
+	}
+}
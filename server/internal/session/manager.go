@@ -1,37 +1,270 @@
 package session
 
-import "github.com/mcherdakov/soa-mafia/server/internal/models"
+import (
+	"log"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
 
+	"github.com/mcherdakov/soa-mafia/pkg/clock"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+)
+
+const evictionInterval = time.Second * 10
+
+type State int
+
+const (
+	// StateStarting is a session that is handing out roles and has not
+	// started the first day yet.
+	StateStarting State = iota
+	StateRunning
+	StateFinished
+	StateAborted
+)
+
+func (s State) String() string {
+	switch s {
+	case StateStarting:
+		return "starting"
+	case StateRunning:
+		return "running"
+	case StateFinished:
+		return "finished"
+	case StateAborted:
+		return "aborted"
+	default:
+		return "unknown"
+	}
+}
+
+// Info is a snapshot of a session registered in the SessionManager.
+type Info struct {
+	ID         int64
+	State      State
+	Players    []string
+	CreatedAt  time.Time
+	FinishedAt time.Time
+}
+
+func (i Info) Active() bool {
+	return i.State == StateStarting || i.State == StateRunning
+}
+
+func (i Info) clone() Info {
+	i.Players = append([]string(nil), i.Players...)
+	return i
+}
+
+// Group is a set of players that start a session together, e.g. a full queue
+// or a private lobby, along with the settings of their game.
+type Group struct {
+	Users  []*models.User
+	Config Config
+}
+
+type entry struct {
+	session *Session
+	info    Info
+}
+
+// SessionManager creates sessions for the groups of users it receives and
+// keeps track of them. Finished and aborted sessions stay available for
+// lookups during the retention window and are evicted afterwards. Aborted
+// sessions no longer accept players or commands.
 type SessionManager struct {
-	input        chan []*models.User
+	retention time.Duration
+	input     chan Group
+	registry  *models.Registry
+	clock     clock.Clock
+
+	mu           sync.RWMutex
 	maxSessionID int64
-	sessions     map[int64]*Session
+	// rng seeds the sessions.
+	rng        *rand.Rand
+	sessions   map[int64]*entry
+	onFinished []func(Result)
+	onEvent    []func(int64, Event)
 }
 
-func NewSessionManager() *SessionManager {
+// NewSessionManager creates a manager. The usernames of the players are
+// released in the registry once their session is over. Sessions run on clk
+// and are seeded from rng.
+func NewSessionManager(retention time.Duration, registry *models.Registry, clk clock.Clock, rng *rand.Rand) *SessionManager {
 	return &SessionManager{
+		retention:    retention,
+		registry:     registry,
+		clock:        clk,
+		rng:          rng,
 		maxSessionID: 0,
-		input:        make(chan []*models.User),
-		sessions:     map[int64]*Session{},
+		input:        make(chan Group),
+		sessions:     map[int64]*entry{},
 	}
 }
 
-func (sm *SessionManager) Chan() chan []*models.User {
+// OnFinished registers a callback that is called with the result of every
+// session played to the end. It must be called before Run.
+func (sm *SessionManager) OnFinished(fn func(Result)) {
+	sm.onFinished = append(sm.onFinished, fn)
+}
+
+// OnEvent registers a callback that is called with the session ID and every
+// event of the session log. It is called from the goroutine running the
+// session and must be registered before Run.
+func (sm *SessionManager) OnEvent(fn func(sessionID int64, e Event)) {
+	sm.onEvent = append(sm.onEvent, fn)
+}
+
+func (sm *SessionManager) Chan() chan Group {
 	return sm.input
 }
 
 func (sm *SessionManager) Run() {
-	for users := range sm.input {
-		sessionID := sm.maxSessionID + 1
+	ticker := sm.clock.NewTicker(evictionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case group, ok := <-sm.input:
+			if !ok {
+				return
+			}
+
+			sm.start(group)
+		case now := <-ticker.C():
+			sm.evict(now)
+		}
+	}
+}
+
+func (sm *SessionManager) start(group Group) {
+	players := make([]string, 0, len(group.Users))
+	for _, user := range group.Users {
+		players = append(players, user.Username)
+	}
+
+	sm.mu.Lock()
+	sm.maxSessionID += 1
+	sessionID := sm.maxSessionID
+
+	session := NewSession(group.Users, sessionID, group.Config, sm.clock, sm.rng.Int63())
+	session.onRunning = func() {
+		sm.setState(sessionID, StateRunning)
+	}
+	session.onEvent = func(e Event) {
+		for _, fn := range sm.onEvent {
+			fn(sessionID, e)
+		}
+	}
 
-		session := NewSession(users, sm.maxSessionID+1)
-		sm.sessions[sessionID] = session
-		sm.maxSessionID += 1
+	sm.sessions[sessionID] = &entry{
+		session: session,
+		info: Info{
+			ID:        sessionID,
+			State:     StateStarting,
+			Players:   players,
+			CreatedAt: sm.clock.Now(),
+		},
+	}
+	sm.mu.Unlock()
+
+	go sm.runSession(sessionID, session)
+}
+
+// runSession runs the session in its own goroutine so that an aborted game
+// does not affect the others.
+func (sm *SessionManager) runSession(sessionID int64, session *Session) {
+	defer func() {
+		for _, user := range session.users {
+			sm.registry.Release(user.Username)
+		}
+	}()
+
+	if err := session.Run(); err != nil {
+		log.Printf("session %d aborted: %v\n", sessionID, err)
+		sm.setState(sessionID, StateAborted)
+
+		return
+	}
+
+	sm.setState(sessionID, StateFinished)
+
+	if result, ok := session.Result(); ok {
+		for _, fn := range sm.onFinished {
+			fn(result)
+		}
+	}
+}
+
+func (sm *SessionManager) setState(sessionID int64, state State) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	e, ok := sm.sessions[sessionID]
+	if !ok {
+		return
+	}
+
+	e.info.State = state
+	if !e.info.Active() {
+		e.info.FinishedAt = sm.clock.Now()
+	}
+}
+
+func (sm *SessionManager) evict(now time.Time) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 
-		go session.Run()
+	for sessionID, e := range sm.sessions {
+		if !e.info.Active() && now.Sub(e.info.FinishedAt) >= sm.retention {
+			delete(sm.sessions, sessionID)
+		}
 	}
 }
 
+// SessionByID returns the session to route the players and commands to, or
+// nil if the session is unknown or aborted.
 func (sm *SessionManager) SessionByID(sessionID int64) *Session {
-	return sm.sessions[sessionID]
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	e, ok := sm.sessions[sessionID]
+	if !ok || e.info.State == StateAborted {
+		return nil
+	}
+
+	return e.session
+}
+
+func (sm *SessionManager) Lookup(sessionID int64) (Info, bool) {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	e, ok := sm.sessions[sessionID]
+	if !ok {
+		return Info{}, false
+	}
+
+	return e.info.clone(), true
+}
+
+// List returns the sessions matching the filter ordered by ID. A nil filter
+// matches every session.
+func (sm *SessionManager) List(filter func(Info) bool) []Info {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	res := []Info{}
+	for _, e := range sm.sessions {
+		if filter == nil || filter(e.info) {
+			res = append(res, e.info.clone())
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ID < res[j].ID
+	})
+
+	return res
 }
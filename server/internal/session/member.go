@@ -0,0 +1,34 @@
+package session
+
+import (
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+// Member is what other services, e.g. the chat, may learn about a player.
+type Member struct {
+	Alive bool
+	Role  proto.Role
+	// Night and Finished describe the session rather than the player.
+	Night    bool
+	Finished bool
+}
+
+// Member returns the state of a player, false if the user does not play in
+// the session.
+func (s *Session) Member(username string) (Member, bool) {
+	if !s.IsMember(username) {
+		return Member{}, false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, alive := s.alive[username]
+
+	return Member{
+		Alive:    alive,
+		Role:     s.roles[username],
+		Night:    s.night,
+		Finished: s.phase.kind == phaseFinished,
+	}, true
+}
@@ -0,0 +1,165 @@
+package session
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+)
+
+// graceCheckInterval is how often a waiting phase checks whether an away
+// player has run out of their reconnect grace period.
+const graceCheckInterval = time.Second
+
+var ErrInvalidToken = errors.New("invalid reconnect token")
+
+// Reconnect attaches a new notification stream to the player and replays the
+// session greeting and the notification of the current phase to it. An
+// eliminated player is put back into omniscient spectator mode.
+func (s *Session) Reconnect(username, token string, stream models.NotificationStream) (*models.User, error) {
+	user := s.user(username)
+	if user == nil {
+		return nil, ErrNotMember
+	}
+
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+
+	s.mu.RLock()
+	finished := s.phase.kind == phaseFinished
+	s.mu.RUnlock()
+
+	if finished {
+		return nil, ErrSessionClosed
+	}
+
+	expected := s.tokens[username]
+	if expected == "" || subtle.ConstantTimeCompare([]byte(expected), []byte(token)) != 1 {
+		return nil, ErrInvalidToken
+	}
+
+	user.Attach(stream)
+
+	for _, n := range []*proto.Notifications{s.greetings[username], s.lastPhase} {
+		if n == nil {
+			continue
+		}
+
+		if err := user.Send(n); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.IsDead(username) {
+		if err := user.Send(s.spectateNotification(true)); err != nil {
+			return nil, err
+		}
+	}
+
+	return user, nil
+}
+
+func (s *Session) Done() <-chan struct{} {
+	return s.done
+}
+
+// IsConnected reports whether the player has a notification stream, false if
+// they are away or do not play in the session.
+func (s *Session) IsConnected(username string) bool {
+	user := s.user(username)
+
+	return user != nil && user.Connected()
+}
+
+// send delivers a private notification. Failures are not fatal for the
+// session, the player is considered away until they reconnect.
+func (s *Session) send(user *models.User, n *proto.Notifications) {
+	if err := user.Send(n); err != nil {
+		log.Printf("session %d: can not notify %s: %v\n", s.sessionID, user.Username, err)
+	}
+}
+
+func (s *Session) broadcast(n *proto.Notifications) {
+	for _, user := range s.users {
+		s.send(user, n)
+	}
+
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	for user := range s.watchers {
+		s.send(user, n)
+	}
+}
+
+// broadcastPhase sends the notification that starts a phase and remembers it
+// so that it can be replayed to reconnecting players.
+func (s *Session) broadcastPhase(n *proto.Notifications) {
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+
+	s.lastPhase = n
+	s.broadcast(n)
+}
+
+// waitingFor reports whether the phase should still wait for the player. Away
+// players are waited for only during the reconnect grace period, after that
+// their action is resolved the same way as a missed deadline.
+func (s *Session) waitingFor(username string) bool {
+	user := s.user(username)
+	return user.Connected() || user.AwayFor() < s.cfg.ReconnectGrace
+}
+
+// waitingForAny reports whether there is an alive player the phase is still
+// waiting for among those that pending reports.
+func (s *Session) waitingForAny(pending func(username string) bool) bool {
+	for username := range s.alive {
+		if pending(username) && s.waitingFor(username) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// awaitCommand waits for the next command from the players. Commands that do
+// not fit the phase are rejected back to the sender. It returns false once the
+// deadline passes or done reports that there is nobody left to wait for. done
+// is rechecked periodically since away players drop out after their grace
+// period.
+func (s *Session) awaitCommand(timeout <-chan time.Time, done func() bool) (Command, bool) {
+	ticker := s.clock.NewTicker(graceCheckInterval)
+	defer ticker.Stop()
+
+	for !done() {
+		select {
+		case cmd := <-s.cmdChan:
+			err := s.accept(cmd)
+			cmd.reply <- err
+
+			if err == nil {
+				return cmd, true
+			}
+		case <-timeout:
+			log.Printf("session %d: day %d phase deadline passed\n", s.sessionID, s.day)
+			return Command{}, false
+		case <-ticker.C():
+		}
+	}
+
+	return Command{}, false
+}
+
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,143 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/pkg/clock"
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+)
+
+var ErrReplayDiverged = errors.New("replay needs more input than the log has")
+
+// nextCommand returns the next command of the current phase, or false once
+// the phase is over. Both outcomes are logged, so that a replay closes every
+// phase at the same point as the original game did, whatever the reason was.
+func (s *Session) nextCommand(timeout <-chan time.Time, done func() bool) (Command, bool) {
+	var (
+		cmd Command
+		ok  bool
+	)
+
+	if s.replay != nil {
+		cmd, ok = s.replay.next()
+	} else {
+		cmd, ok = s.awaitCommand(timeout, done)
+	}
+
+	if !ok {
+		s.emit(Event{Type: EventPhaseClosed})
+		return Command{}, false
+	}
+
+	s.emit(Event{
+		Type:     EventCommandReceived,
+		Username: cmd.Username,
+		Command:  newCommandRecord(cmd.Cmd),
+	})
+
+	return cmd, true
+}
+
+// replayer feeds the logged commands and phase ends to a session.
+type replayer struct {
+	events []Event
+}
+
+func (r *replayer) next() (Command, bool) {
+	for len(r.events) > 0 {
+		e := r.events[0]
+		r.events = r.events[1:]
+
+		switch e.Type {
+		case EventPhaseClosed:
+			return Command{}, false
+		case EventCommandReceived:
+			cmd, err := e.Command.proto()
+			if err != nil {
+				panic(fmt.Errorf("event %d: %w", e.Seq, err))
+			}
+
+			return Command{Cmd: cmd, Username: e.Username}, true
+		}
+	}
+
+	// the session loop can not proceed on its own, Run turns this into an
+	// aborted game
+	panic(ErrReplayDiverged)
+}
+
+type discardStream struct{}
+
+func (discardStream) Send(*proto.Notifications) error {
+	return nil
+}
+
+// Replay runs the session logic over a log and returns the events it emitted.
+// Notifications are discarded and no time passes between phases.
+func Replay(events []Event) ([]Event, error) {
+	if len(events) == 0 || events[0].Type != EventSessionCreated {
+		return nil, fmt.Errorf("log does not start with %s", EventSessionCreated)
+	}
+
+	created := events[0]
+	if created.Settings == nil {
+		return nil, fmt.Errorf("%s event has no settings", EventSessionCreated)
+	}
+
+	cfg, err := created.Settings.config(len(created.Players))
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]*models.User, 0, len(created.Players))
+	for _, username := range created.Players {
+		users = append(users, models.NewUser(username, discardStream{}))
+	}
+
+	s := NewSession(users, created.SessionID, cfg, clock.Real(), created.Seed)
+	s.introDelay = 0
+	s.replay = &replayer{events: events[1:]}
+
+	// an aborted replay is reported through its events
+	_ = s.Run()
+
+	return s.events, nil
+}
+
+// Verify compares a replay with the original log and returns the first
+// difference. Timestamps are ignored.
+func Verify(original, replayed []Event) error {
+	for i := 0; i < len(original) && i < len(replayed); i++ {
+		a, err := withoutTime(original[i])
+		if err != nil {
+			return err
+		}
+
+		b, err := withoutTime(replayed[i])
+		if err != nil {
+			return err
+		}
+
+		if !bytes.Equal(a, b) {
+			return fmt.Errorf("event %d differs: logged %s, replayed %s", original[i].Seq, a, b)
+		}
+	}
+
+	if len(original) != len(replayed) {
+		return fmt.Errorf("logged %d events, replayed %d", len(original), len(replayed))
+	}
+
+	return nil
+}
+
+// withoutTime encodes the event without its timestamp, which also makes nil
+// and empty slices equal.
+func withoutTime(e Event) ([]byte, error) {
+	e.Time = time.Time{}
+	return json.Marshal(e)
+}
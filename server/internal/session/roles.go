@@ -0,0 +1,85 @@
+package session
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+// roleDistribution describes how many players of each special role a session
+// of the given size gets. Everyone else is a civilian.
+type roleDistribution struct {
+	players   int
+	mafia     int
+	detective int
+	doctor    int
+}
+
+var roleDistributions = []roleDistribution{
+	{players: 4, mafia: 1, detective: 1, doctor: 0},
+	{players: 5, mafia: 1, detective: 1, doctor: 1},
+	{players: 6, mafia: 2, detective: 1, doctor: 1},
+	{players: 7, mafia: 2, detective: 1, doctor: 1},
+	{players: 8, mafia: 2, detective: 1, doctor: 1},
+	{players: 9, mafia: 3, detective: 1, doctor: 1},
+	{players: 10, mafia: 3, detective: 1, doctor: 1},
+	{players: 11, mafia: 3, detective: 1, doctor: 1},
+	{players: 12, mafia: 4, detective: 1, doctor: 1},
+}
+
+func SupportedCapacities() []int {
+	capacities := make([]int, 0, len(roleDistributions))
+	for _, d := range roleDistributions {
+		capacities = append(capacities, d.players)
+	}
+
+	return capacities
+}
+
+func distributionFor(players int) (roleDistribution, bool) {
+	for _, d := range roleDistributions {
+		if d.players == players {
+			return d, true
+		}
+	}
+
+	return roleDistribution{}, false
+}
+
+func (d roleDistribution) roles() []proto.Role {
+	roles := make([]proto.Role, 0, d.players)
+
+	for i := 0; i < d.mafia; i++ {
+		roles = append(roles, proto.Role_MAFIA)
+	}
+
+	for i := 0; i < d.detective; i++ {
+		roles = append(roles, proto.Role_DETECITVE)
+	}
+
+	for i := 0; i < d.doctor; i++ {
+		roles = append(roles, proto.Role_DOCTOR)
+	}
+
+	for len(roles) < d.players {
+		roles = append(roles, proto.Role_CIVILIAN)
+	}
+
+	return roles
+}
+
+func genRoles(players int, rng *rand.Rand) ([]proto.Role, error) {
+	d, ok := distributionFor(players)
+	if !ok {
+		return nil, fmt.Errorf("unsupported session capacity %d", players)
+	}
+
+	roles := d.roles()
+
+	rng.Shuffle(len(roles), func(i, j int) {
+		roles[i], roles[j] = roles[j], roles[i]
+	})
+
+	return roles, nil
+}
@@ -4,90 +4,277 @@ import (
 	"fmt"
 	"log"
 	"math/rand"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/mcherdakov/soa-mafia/pkg/clock"
 	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
 	"github.com/mcherdakov/soa-mafia/server/internal/models"
+	"github.com/mcherdakov/soa-mafia/server/internal/rules"
 )
 
-const SessionCapacity = 4
+// IntroDelay gives the players time to read their roles before the first day.
+const IntroDelay = time.Second * 5
 
 type Command struct {
 	Cmd      *proto.Commands
 	Username string
+
+	// phase is the phase the command was submitted in, the verdict of the
+	// game loop is sent to reply.
+	phase int64
+	reply chan error
+}
+
+// Result is the outcome of a session that was played to the end.
+type Result struct {
+	SessionID  int64
+	Ruleset    string
+	Winner     proto.Role
+	Roles      map[string]proto.Role
+	Days       []Day
+	StartedAt  time.Time
+	FinishedAt time.Time
 }
 
 type Session struct {
 	users []*models.User
+
+	// mu guards alive, roles, phase and night, which are read by Submit and
+	// Member.
+	mu    sync.RWMutex
 	alive map[string]*models.User
 	roles map[string]proto.Role
+	phase phase
+	night bool
 
+	cfg       Config
+	clock     clock.Clock
 	sessionID int64
 	day       int64
 	cmdChan   chan Command
+	done      chan struct{}
+	// onRunning is called once the roles are handed out and the first day
+	// starts.
+	onRunning  func()
+	introDelay time.Duration
+
+	// seed drives every random decision of the session, so that the event
+	// log can be replayed.
+	seed   int64
+	rng    *rand.Rand
+	events []Event
+	// onEvent is called with every event as soon as it is emitted.
+	onEvent func(Event)
+	// replay is set when the session is driven by an event log instead of
+	// the players.
+	replay *replayer
 
 	killed      *string
 	mafiaReveal *string
+	winner      *proto.Role
+	days        []Day
+	startedAt   time.Time
+	finishedAt  time.Time
+	// lastHealed is guarded by mu since Submit checks heals against it.
+	lastHealed *string
+
+	// replayMu guards what is replayed to reconnecting players and orders
+	// replays with phase notifications.
+	replayMu  sync.Mutex
+	tokens    map[string]string
+	greetings map[string]*proto.Notifications
+	lastPhase *proto.Notifications
+
+	// watchMu guards the spectators, the value tells whether they are
+	// omniscient.
+	watchMu  sync.Mutex
+	watchers map[*models.User]bool
 }
 
-func NewSession(users []*models.User, sessionID int64) *Session {
+// NewSession creates a session that measures its phases with clk. Every
+// random decision, including the roles of the players, is derived from seed.
+func NewSession(users []*models.User, sessionID int64, cfg Config, clk clock.Clock, seed int64) *Session {
 	alive := make(map[string]*models.User, len(users))
 
 	for _, user := range users {
 		alive[user.Username] = user
+		// the reconnect grace is measured on the session clock
+		user.UseClock(clk)
 	}
 
 	return &Session{
-		users:     users,
-		alive:     alive,
-		sessionID: sessionID,
-		roles:     make(map[string]proto.Role),
-		cmdChan:   make(chan Command),
+		users:      users,
+		alive:      alive,
+		cfg:        cfg,
+		clock:      clk,
+		sessionID:  sessionID,
+		roles:      make(map[string]proto.Role),
+		cmdChan:    make(chan Command),
+		done:       make(chan struct{}),
+		introDelay: IntroDelay,
+		seed:       seed,
+		rng:        rand.New(rand.NewSource(seed)),
+		tokens:     make(map[string]string, len(users)),
+		greetings:  make(map[string]*proto.Notifications, len(users)),
+		watchers:   make(map[*models.User]bool),
 	}
 }
 
-func (s *Session) CmdChan() chan Command {
-	return s.cmdChan
-}
-
-func (s *Session) Run() {
+// Run plays the game until it ends. A non-nil error means the session was
+// aborted, the players are notified about it before Run returns.
+func (s *Session) Run() (err error) {
 	log.Printf("running session %d\n", s.sessionID)
+	s.startedAt = s.clock.Now()
+
+	defer close(s.done)
+	defer s.setPhase(phaseFinished, nil)
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
 
-	roles := s.genRoles()
+		if err != nil {
+			s.abort(err)
+		}
+	}()
 
+	players := make([]string, 0, len(s.users))
+	for _, user := range s.users {
+		players = append(players, user.Username)
+	}
+
+	s.emit(Event{
+		Type:      EventSessionCreated,
+		SessionID: s.sessionID,
+		Seed:      s.seed,
+		Players:   players,
+		Settings:  newSettings(s.cfg),
+	})
+
+	roles, err := genRoles(s.cfg.Capacity, s.rng)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
 	for i, user := range s.users {
 		s.roles[user.Username] = roles[i]
+	}
+	s.mu.Unlock()
+
+	s.emit(Event{
+		Type:  EventRolesAssigned,
+		Roles: roles,
+	})
+
+	mafia := s.mafia()
+
+	var don *string
+	if s.cfg.KillRule == KillDon {
+		don = s.don()
+	}
+
+	s.greet(roles, mafia, don)
+
+	s.clock.Sleep(s.introDelay)
+
+	if s.onRunning != nil {
+		s.onRunning()
+	}
+
+	for !s.runRound() {
+	}
 
-		sessionNotification := &proto.Notifications{
+	return nil
+}
+
+// greet hands out the roles along with reconnect tokens.
+func (s *Session) greet(roles []proto.Role, mafia []string, don *string) {
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+
+	for i, user := range s.users {
+		s.tokens[user.Username] = newToken()
+
+		enterSession := &proto.EnterSessionNotification{
+			SessionId:      s.sessionID,
+			Role:           roles[i],
+			ReconnectToken: s.tokens[user.Username],
+		}
+
+		if roles[i] == proto.Role_MAFIA {
+			for _, username := range mafia {
+				if username != user.Username {
+					enterSession.Teammates = append(enterSession.Teammates, username)
+				}
+			}
+
+			enterSession.Don = don
+		}
+
+		s.greetings[user.Username] = &proto.Notifications{
 			Notification: &proto.Notifications_EnterSession{
-				EnterSession: &proto.EnterSessionNotification{
-					SessionId: s.sessionID,
-					Role:      roles[i],
-				},
+				EnterSession: enterSession,
 			},
 		}
 
-		if err := user.Notifications.Send(sessionNotification); err != nil {
-			log.Println(err)
-		}
+		s.send(user, s.greetings[user.Username])
 	}
+}
 
-	time.Sleep(time.Second * 5)
+// Result returns the outcome once Run has returned without an error.
+func (s *Session) Result() (Result, bool) {
+	if s.winner == nil {
+		return Result{}, false
+	}
 
-	for {
-		end, err := s.runRound()
-		if err != nil {
-			log.Fatalln(err)
-		}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 
-		if end {
-			return
-		}
+	roles := make(map[string]proto.Role, len(s.roles))
+	for username, role := range s.roles {
+		roles[username] = role
 	}
+
+	return Result{
+		SessionID:  s.sessionID,
+		Ruleset:    s.cfg.Ruleset.Name(),
+		Winner:     *s.winner,
+		Roles:      roles,
+		Days:       s.days,
+		StartedAt:  s.startedAt,
+		FinishedAt: s.finishedAt,
+	}, true
 }
 
-func (s *Session) runRound() (bool, error) {
+// abort tells every player that is still reachable that the game is over
+// without a winner.
+func (s *Session) abort(reason error) {
+	s.emit(Event{
+		Type:   EventGameEnded,
+		Reason: reason.Error(),
+	})
+
+	result := &proto.Notifications{
+		Notification: &proto.Notifications_ResultNotification{
+			ResultNotification: &proto.ResultNotification{
+				Aborted: true,
+				Reason:  reason.Error(),
+			},
+		},
+	}
+
+	s.broadcast(result)
+}
+
+func (s *Session) runRound() bool {
 	s.day += 1
+	s.days = append(s.days, Day{Number: s.day})
+
+	dayPhase := s.cfg.Ruleset.DayPhase(s.day)
+	dayDeadline := s.startPhase(dayPhase, false)
 
 	roundStart := &proto.Notifications{
 		Notification: &proto.Notifications_RoundStart{
@@ -96,229 +283,334 @@ func (s *Session) runRound() (bool, error) {
 				KilledUsername: s.killed,
 				MafiaUsername:  s.mafiaReveal,
 				Remaining:      s.makeRemaining(),
+				Deadline:       dayDeadline.UnixMilli(),
+				KilledRole:     s.revealRole(s.killed),
+				Phase:          phaseProto(dayPhase),
 			},
 		},
 	}
 
-	for _, user := range s.users {
-		if err := user.Notifications.Send(roundStart); err != nil {
-			return false, err
-		}
-	}
+	s.broadcastPhase(roundStart)
 
-	var votedOut *string
+	var vote voteOutcome
 
-	if s.day == 1 {
-		if err := s.awaitPass(); err != nil {
-			return false, err
+	if dayPhase == rules.PhaseVote {
+		vote = s.dayVote(dayDeadline)
+		s.today().Votes = vote.votes
+		s.today().RunoffVotes = vote.runoffVotes
+		s.today().VotedOut = vote.votedOut
+
+		if vote.votedOut != nil {
+			s.kill(*vote.votedOut, "vote")
 		}
 	} else {
-		voteResult, err := s.awaitVote()
-		if err != nil {
-			return false, err
-		}
-
-		votedOut = &voteResult
-		delete(s.alive, voteResult)
+		s.awaitPass(dayDeadline)
 	}
 
 	if s.checkGameEnd() {
-		return true, nil
+		return true
 	}
 
+	nightPhase := s.cfg.Ruleset.NightPhase(s.day)
+	nightDeadline := s.startPhase(nightPhase, true)
+
 	nightTime := &proto.Notifications{
 		Notification: &proto.Notifications_NightTime{
 			NightTime: &proto.NightTimeNotification{
-				VotedOut:  votedOut,
-				Remaining: s.makeRemaining(),
+				VotedOut:     vote.votedOut,
+				Remaining:    s.makeRemaining(),
+				Deadline:     nightDeadline.UnixMilli(),
+				Votes:        vote.finalVotes(),
+				Tied:         vote.tied,
+				RandomPick:   vote.randomPick,
+				VotedOutRole: s.revealRole(vote.votedOut),
+				Phase:        phaseProto(nightPhase),
 			},
 		},
 	}
 
-	for _, user := range s.users {
-		if err := user.Notifications.Send(nightTime); err != nil {
-			return false, err
-		}
-	}
+	s.broadcastPhase(nightTime)
 
-	if s.day == 1 {
-		if err := s.awaitPass(); err != nil {
-			return false, err
-		}
-	} else {
-		if err := s.awaitMafiaAndDetective(); err != nil {
-			return false, err
-		}
+	if nightPhase == rules.PhaseNight {
+		s.awaitMafiaAndDetective(nightDeadline)
 
 		if s.checkGameEnd() {
-			return true, nil
+			return true
 		}
+	} else {
+		s.awaitPass(nightDeadline)
 	}
 
-	return false, nil
+	return false
 }
 
-func (s *Session) checkGameEnd() bool {
-	mafiaCount := 0
-	civilianCount := 0
-
-	for username := range s.alive {
-		if s.roles[username] == proto.Role_CIVILIAN || s.roles[username] == proto.Role_DETECITVE {
-			civilianCount++
-		}
+// startPhase opens the phase of the day or the night for commands and
+// returns its deadline.
+func (s *Session) startPhase(p rules.Phase, night bool) time.Time {
+	s.emit(Event{
+		Type:  EventPhaseChanged,
+		Phase: phaseProto(p).String(),
+	})
 
-		if s.roles[username] == proto.Role_MAFIA {
-			mafiaCount++
-		}
+	s.mu.Lock()
+	s.night = night
+	s.mu.Unlock()
+
+	switch p {
+	case rules.PhaseVote:
+		s.setPhase(phaseVote, nil)
+		return s.clock.Now().Add(s.cfg.VoteTimeout)
+	case rules.PhaseNight:
+		s.setPhase(phaseNight, nil)
+		return s.clock.Now().Add(s.cfg.NightTimeout)
+	default:
+		s.setPhase(phasePass, nil)
+		return s.clock.Now().Add(s.cfg.DiscussionTimeout)
 	}
+}
 
-	var winRole *proto.Role
+func phaseProto(p rules.Phase) proto.Phase {
+	switch p {
+	case rules.PhaseVote:
+		return proto.Phase_PHASE_VOTE
+	case rules.PhaseNight:
+		return proto.Phase_PHASE_NIGHT
+	default:
+		return proto.Phase_PHASE_PASS
+	}
+}
 
-	if mafiaCount >= civilianCount {
-		winRole = ptr(proto.Role_MAFIA)
+// revealRole returns the role of the player who left the game if the ruleset
+// announces it.
+func (s *Session) revealRole(username *string) *proto.Role {
+	if username == nil || !s.cfg.Ruleset.RevealRoleOnDeath() {
+		return nil
 	}
 
-	if mafiaCount == 0 {
-		winRole = ptr(proto.Role_CIVILIAN)
+	return ptr(s.roles[*username])
+}
+
+func (s *Session) checkGameEnd() bool {
+	alive := make(map[string]proto.Role, len(s.alive))
+	for username := range s.alive {
+		alive[username] = s.roles[username]
 	}
 
-	if winRole == nil {
+	winner, ok := s.cfg.Ruleset.Winner(alive)
+	if !ok {
 		return false
 	}
 
+	s.winner = &winner
+	s.finishedAt = s.clock.Now()
+
+	s.emit(Event{
+		Type:   EventGameEnded,
+		Winner: &winner,
+	})
+
 	result := &proto.Notifications{
 		Notification: &proto.Notifications_ResultNotification{
 			ResultNotification: &proto.ResultNotification{
-				Winner: *winRole,
+				Winner: winner,
 			},
 		},
 	}
 
-	for _, user := range s.users {
-		if err := user.Notifications.Send(result); err != nil {
-			log.Println(err)
-		}
-	}
+	s.broadcast(result)
 
 	return true
 }
 
-func (s *Session) awaitMafiaAndDetective() error {
-	count := 0
+// awaitMafiaAndDetective collects night actions until the detective and the
+// doctor have acted and the mafia agreed on a victim, or the deadline passes.
+// Missing actions are skipped. Mafia members may change their pick until the
+// night ends. The kill is cancelled if the doctor healed its target.
+func (s *Session) awaitMafiaAndDetective(deadline time.Time) {
+	s.killed = nil
+	s.mafiaReveal = nil
+
+	awaited := map[string]struct{}{}
 	for username, role := range s.roles {
-		if (role == proto.Role_MAFIA || role == proto.Role_DETECITVE) && s.alive[username] != nil {
-			count++
+		if role != proto.Role_CIVILIAN && role != proto.Role_MAFIA && s.alive[username] != nil {
+			awaited[username] = struct{}{}
 		}
 	}
 
-	for i := 0; i < count; i++ {
-		cmd := <-s.cmdChan
+	picks := map[string]string{}
+	var heal *string
+	var investigations []Command
+
+	timeout := s.clock.After(deadline.Sub(s.clock.Now()))
+
+	for {
+		cmd, ok := s.nextCommand(timeout, func() bool {
+			return !s.waitingForAny(func(username string) bool {
+				if s.roles[username] == proto.Role_MAFIA {
+					_, picked := picks[username]
+					return !picked || !s.killSettled(picks)
+				}
+
+				_, ok := awaited[username]
+				return ok
+			})
+		})
+		if !ok {
+			break
+		}
 
-		switch cmd.Cmd.Command.(type) {
-		case *proto.Commands_KillCommand:
-			if s.roles[cmd.Username] != proto.Role_MAFIA {
-				return fmt.Errorf("invalid role: expected mafia")
+		if kill, ok := cmd.Cmd.GetCommand().(*proto.Commands_KillCommand); ok {
+			if s.roles[cmd.Username] != proto.Role_MAFIA || s.alive[cmd.Username] == nil {
+				s.ignore(cmd)
+				continue
 			}
 
-			s.killed = &cmd.Cmd.GetKillCommand().Username
-			delete(s.alive, *s.killed)
+			picks[cmd.Username] = kill.KillCommand.Username
+			s.sendMafiaPicks(picks)
+			s.revealNightAction(cmd.Username, kill.KillCommand.Username)
+
+			continue
+		}
+
+		if _, ok := awaited[cmd.Username]; !ok {
+			s.ignore(cmd)
+			continue
+		}
+
+		switch cmd.Cmd.GetCommand().(type) {
 		case *proto.Commands_CheckCommand:
 			if s.roles[cmd.Username] != proto.Role_DETECITVE {
-				return fmt.Errorf("invalid role: expected detective")
+				s.ignore(cmd)
+				continue
 			}
 
-			check := cmd.Cmd.GetCheckCommand().Username
-			if s.roles[check] == proto.Role_MAFIA {
-				s.mafiaReveal = &check
-			} else {
-				s.mafiaReveal = nil
+			investigations = append(investigations, cmd)
+			s.revealNightAction(cmd.Username, cmd.Cmd.GetCheckCommand().Username)
+		case *proto.Commands_HealCommand:
+			if s.roles[cmd.Username] != proto.Role_DOCTOR || s.isRepeatHeal(cmd.Cmd.GetHealCommand().Username) {
+				s.ignore(cmd)
+				continue
 			}
+
+			heal = &cmd.Cmd.GetHealCommand().Username
+			s.revealNightAction(cmd.Username, *heal)
+		default:
+			s.ignore(cmd)
+			continue
 		}
-	}
 
-	return nil
-}
+		delete(awaited, cmd.Username)
+		s.markActed(cmd.Username)
+	}
 
-func (s *Session) awaitVote() (string, error) {
-	votes := make(map[string]string, len(s.alive))
-	count := map[string]int{}
+	s.mu.Lock()
+	s.lastHealed = heal
+	s.mu.Unlock()
 
-	for cmd := range s.cmdChan {
-		vote, ok := cmd.Cmd.Command.(*proto.Commands_VoteCommand)
-		if !ok {
-			return "", fmt.Errorf("invalid command, expected vote")
-		}
+	s.today().Healed = heal
 
-		if _, ok := s.alive[cmd.Username]; !ok {
-			return "", fmt.Errorf("dead user is trying to vote")
-		}
+	kill := s.resolveKill(picks)
+	var protected *string
 
-		votes[cmd.Username] = vote.VoteCommand.Username
-		count[vote.VoteCommand.Username] += 1
-
-		if len(votes) == len(s.alive) {
-			break
+	for _, action := range s.cfg.Ruleset.NightOrder() {
+		switch action {
+		case rules.ActionHeal:
+			protected = heal
+		case rules.ActionKill:
+			if kill != nil && (protected == nil || *protected != *kill) {
+				s.killed = kill
+				s.today().Killed = kill
+				s.kill(*kill, "kill")
+			}
+		case rules.ActionCheck:
+			for _, cmd := range investigations {
+				if _, ok := s.alive[cmd.Username]; !ok {
+					continue
+				}
+
+				check := cmd.Cmd.GetCheckCommand().Username
+				if s.cfg.PublishDetectiveFindings && s.roles[check] == proto.Role_MAFIA {
+					s.mafiaReveal = &check
+				}
+
+				s.sendInvestigationResult(cmd.Username, check)
+				s.today().Checks = append(s.today().Checks, Check{
+					Detective: cmd.Username,
+					Suspect:   check,
+					IsMafia:   s.roles[check] == proto.Role_MAFIA,
+				})
+			}
 		}
 	}
+}
 
-	curMax := 0
-	curUser := ""
-
-	for username, cnt := range count {
-		if cnt > curMax {
-			curMax = cnt
-			curUser = username
-		}
+// sendInvestigationResult privately tells the detective whether the suspect
+// is mafia.
+func (s *Session) sendInvestigationResult(detective, suspect string) {
+	result := &proto.Notifications{
+		Notification: &proto.Notifications_InvestigationResult{
+			InvestigationResult: &proto.InvestigationResultNotification{
+				Username: suspect,
+				IsMafia:  s.roles[suspect] == proto.Role_MAFIA,
+			},
+		},
 	}
 
-	return curUser, nil
+	s.send(s.user(detective), result)
+}
+
+// isRepeatHeal reports whether healing the target is forbidden because the
+// doctor healed the same player last night.
+func (s *Session) isRepeatHeal(target string) bool {
+	return s.cfg.ForbidRepeatHeal && s.lastHealed != nil && *s.lastHealed == target
 }
 
-func (s *Session) awaitPass() error {
-	alreadyAwaited := make(map[string]struct{}, SessionCapacity)
+// awaitPass waits until every alive player has passed or the deadline passes.
+// Players who did not pass are treated as if they did.
+func (s *Session) awaitPass(deadline time.Time) {
+	alreadyAwaited := make(map[string]struct{}, len(s.alive))
 
-	for cmd := range s.cmdChan {
-		_, ok := cmd.Cmd.Command.(*proto.Commands_PassCommand)
+	timeout := s.clock.After(deadline.Sub(s.clock.Now()))
+
+	for {
+		cmd, ok := s.nextCommand(timeout, func() bool {
+			return !s.waitingForAny(func(username string) bool {
+				_, passed := alreadyAwaited[username]
+				return !passed
+			})
+		})
 		if !ok {
-			return fmt.Errorf("invalid command, expected pass")
+			return
 		}
 
-		if _, ok := alreadyAwaited[cmd.Username]; ok {
+		if _, ok := cmd.Cmd.GetCommand().(*proto.Commands_PassCommand); !ok || s.alive[cmd.Username] == nil {
+			s.ignore(cmd)
 			continue
 		}
 
 		alreadyAwaited[cmd.Username] = struct{}{}
-
-		if len(alreadyAwaited) == SessionCapacity {
-			break
-		}
+		s.markActed(cmd.Username)
 	}
-
-	return nil
 }
 
-func (s *Session) genRoles() []proto.Role {
-	var roles []proto.Role
+// ignore drops a command that does not fit the current phase, e.g. an action
+// that arrived after its phase deadline.
+func (s *Session) ignore(cmd Command) {
+	log.Printf("session %d: ignoring command %T from %s\n", s.sessionID, cmd.Cmd.GetCommand(), cmd.Username)
+}
 
-	switch SessionCapacity {
-	case 4:
-		roles = []proto.Role{
-			proto.Role_MAFIA,
-			proto.Role_DETECITVE,
-			proto.Role_CIVILIAN,
-			proto.Role_CIVILIAN,
+func (s *Session) user(username string) *models.User {
+	for _, user := range s.users {
+		if user.Username == username {
+			return user
 		}
-	default:
-		log.Fatalln("unsupported session capacity")
 	}
 
-	rand.Shuffle(len(roles), func(i, j int) {
-		roles[i], roles[j] = roles[j], roles[i]
-	})
-
-	return roles
+	return nil
 }
 
+// makeRemaining returns the alive players sorted by name, so that the
+// notifications do not depend on the map order.
 func (s *Session) makeRemaining() []string {
 	res := []string{}
 
@@ -326,6 +618,8 @@ func (s *Session) makeRemaining() []string {
 		res = append(res, username)
 	}
 
+	sort.Strings(res)
+
 	return res
 }
 
@@ -0,0 +1,122 @@
+package session
+
+import (
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+	"github.com/mcherdakov/soa-mafia/server/internal/models"
+)
+
+// Watch streams the public notifications of the session to a spectator,
+// starting with the notification of the current phase. Omniscient
+// spectators also see the roles and the night actions.
+func (s *Session) Watch(username string, omniscient bool, stream models.NotificationStream) (*models.User, error) {
+	s.replayMu.Lock()
+	defer s.replayMu.Unlock()
+
+	s.mu.RLock()
+	finished := s.phase.kind == phaseFinished
+	s.mu.RUnlock()
+
+	if finished {
+		return nil, ErrSessionClosed
+	}
+
+	// holding watchMu while catching up keeps broadcasts from overtaking
+	// the replayed notifications
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	user := models.NewUser(username, stream)
+
+	for _, n := range []*proto.Notifications{s.spectateNotification(omniscient), s.lastPhase} {
+		if n == nil {
+			continue
+		}
+
+		if err := user.Send(n); err != nil {
+			return nil, err
+		}
+	}
+
+	s.watchers[user] = omniscient
+
+	return user, nil
+}
+
+// Unwatch stops the notifications of a spectator.
+func (s *Session) Unwatch(user *models.User) {
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	delete(s.watchers, user)
+}
+
+// IsDead reports whether the user is an eliminated player of the session.
+func (s *Session) IsDead(username string) bool {
+	if !s.IsMember(username) {
+		return false
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, alive := s.alive[username]
+
+	return !alive
+}
+
+func (s *Session) spectateNotification(omniscient bool) *proto.Notifications {
+	spectate := &proto.SpectateNotification{
+		SessionId:  s.sessionID,
+		Omniscient: omniscient,
+	}
+
+	if omniscient {
+		s.mu.RLock()
+		for _, user := range s.users {
+			if role, ok := s.roles[user.Username]; ok {
+				spectate.Roles = append(spectate.Roles, &proto.PlayerRole{
+					Username: user.Username,
+					Role:     role,
+				})
+			}
+		}
+		s.mu.RUnlock()
+	}
+
+	return &proto.Notifications{
+		Notification: &proto.Notifications_Spectate{
+			Spectate: spectate,
+		},
+	}
+}
+
+// reveal sends a notification that only omniscient spectators may see,
+// which includes every eliminated player.
+func (s *Session) reveal(n *proto.Notifications) {
+	for _, user := range s.users {
+		if _, ok := s.alive[user.Username]; !ok {
+			s.send(user, n)
+		}
+	}
+
+	s.watchMu.Lock()
+	defer s.watchMu.Unlock()
+
+	for user, omniscient := range s.watchers {
+		if omniscient {
+			s.send(user, n)
+		}
+	}
+}
+
+func (s *Session) revealNightAction(username, target string) {
+	s.reveal(&proto.Notifications{
+		Notification: &proto.Notifications_NightAction{
+			NightAction: &proto.NightActionNotification{
+				Username: username,
+				Role:     s.roles[username],
+				Target:   target,
+			},
+		},
+	})
+}
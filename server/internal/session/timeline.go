@@ -0,0 +1,28 @@
+package session
+
+import (
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+// Day is what happened during one day of the session and the night after it.
+type Day struct {
+	Number int64
+	// Votes is the tally of the day vote.
+	Votes []*proto.Vote
+	// RunoffVotes is the tally of the runoff that followed a tied vote.
+	RunoffVotes []*proto.Vote
+	VotedOut    *string
+	Killed      *string
+	Healed      *string
+	Checks      []Check
+}
+
+type Check struct {
+	Detective string
+	Suspect   string
+	IsMafia   bool
+}
+
+func (s *Session) today() *Day {
+	return &s.days[len(s.days)-1]
+}
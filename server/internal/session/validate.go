@@ -0,0 +1,225 @@
+package session
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+var (
+	ErrEmptyCommand  = errors.New("command is empty")
+	ErrNotMember     = errors.New("user is not a member of the session")
+	ErrSessionClosed = errors.New("session is finished")
+	ErrPlayerDead    = errors.New("dead players can not act")
+	ErrWrongPhase    = errors.New("command is not allowed in the current phase")
+	ErrPhaseOver     = errors.New("the phase the command was sent in is over")
+	ErrWrongRole     = errors.New("command is not allowed for the player's role")
+	ErrAlreadyActed  = errors.New("player has already acted in this phase")
+	ErrUnknownTarget = errors.New("target is not a player of the session")
+	ErrDeadTarget    = errors.New("target is dead")
+	ErrNotCandidate  = errors.New("target is not a runoff candidate")
+	ErrRepeatHeal    = errors.New("can not heal the same player two nights in a row")
+)
+
+type phaseKind int
+
+const (
+	phaseStarting phaseKind = iota
+	phasePass
+	phaseVote
+	phaseNight
+	phaseFinished
+)
+
+// phase is the part of the game state commands are validated against.
+type phase struct {
+	// seq grows with every phase, so that a command can be matched with the
+	// phase it was sent in.
+	seq  int64
+	kind phaseKind
+	// candidates limits vote targets during a runoff, nil otherwise.
+	candidates map[string]struct{}
+	acted      map[string]struct{}
+}
+
+// Submit validates the command against session membership and the current
+// game state and passes it to the game loop. The phase may end before the
+// loop takes the command, so the loop validates it once more and Submit
+// returns its verdict.
+func (s *Session) Submit(ctx context.Context, cmd Command) error {
+	s.mu.RLock()
+	cmd.phase = s.phase.seq
+	s.mu.RUnlock()
+
+	if err := s.validate(cmd); err != nil {
+		return err
+	}
+
+	cmd.reply = make(chan error, 1)
+
+	select {
+	case s.cmdChan <- cmd:
+	case <-s.done:
+		return ErrSessionClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	select {
+	case err := <-cmd.reply:
+		return err
+	case <-s.done:
+		return ErrSessionClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// accept validates the command against the phase the loop is in, which may
+// already be a later one than the command was sent in.
+func (s *Session) accept(cmd Command) error {
+	s.mu.RLock()
+	stale := cmd.phase != s.phase.seq
+	s.mu.RUnlock()
+
+	if stale {
+		return ErrPhaseOver
+	}
+
+	return s.validate(cmd)
+}
+
+func (s *Session) validate(cmd Command) error {
+	if cmd.Cmd.GetCommand() == nil {
+		return ErrEmptyCommand
+	}
+
+	if !s.IsMember(cmd.Username) {
+		return ErrNotMember
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.phase.kind == phaseFinished {
+		return ErrSessionClosed
+	}
+
+	if _, ok := s.alive[cmd.Username]; !ok {
+		return ErrPlayerDead
+	}
+
+	switch c := cmd.Cmd.Command.(type) {
+	case *proto.Commands_PassCommand:
+		if s.phase.kind != phasePass {
+			return ErrWrongPhase
+		}
+	case *proto.Commands_VoteCommand:
+		if s.phase.kind != phaseVote {
+			return ErrWrongPhase
+		}
+
+		if !c.VoteCommand.Abstain {
+			if err := s.validateTarget(c.VoteCommand.Username); err != nil {
+				return err
+			}
+
+			if _, ok := s.phase.candidates[c.VoteCommand.Username]; s.phase.candidates != nil && !ok {
+				return ErrNotCandidate
+			}
+		}
+	case *proto.Commands_KillCommand:
+		if err := s.validateNightAction(cmd.Username, proto.Role_MAFIA, c.KillCommand.Username); err != nil {
+			return err
+		}
+	case *proto.Commands_CheckCommand:
+		if err := s.validateNightAction(cmd.Username, proto.Role_DETECITVE, c.CheckCommand.Username); err != nil {
+			return err
+		}
+	case *proto.Commands_HealCommand:
+		if err := s.validateNightAction(cmd.Username, proto.Role_DOCTOR, c.HealCommand.Username); err != nil {
+			return err
+		}
+
+		if s.isRepeatHeal(c.HealCommand.Username) {
+			return ErrRepeatHeal
+		}
+	}
+
+	// mafia members may change their pick until the night ends
+	if _, ok := cmd.Cmd.Command.(*proto.Commands_KillCommand); ok {
+		return nil
+	}
+
+	if _, ok := s.phase.acted[cmd.Username]; ok {
+		return ErrAlreadyActed
+	}
+
+	return nil
+}
+
+func (s *Session) validateNightAction(username string, role proto.Role, target string) error {
+	if s.roles[username] != role {
+		return ErrWrongRole
+	}
+
+	if s.phase.kind != phaseNight {
+		return ErrWrongPhase
+	}
+
+	return s.validateTarget(target)
+}
+
+func (s *Session) validateTarget(target string) error {
+	if !s.IsMember(target) {
+		return ErrUnknownTarget
+	}
+
+	if _, ok := s.alive[target]; !ok {
+		return ErrDeadTarget
+	}
+
+	return nil
+}
+
+// IsMember reports whether the user plays in the session, alive or not.
+func (s *Session) IsMember(username string) bool {
+	return s.user(username) != nil
+}
+
+func (s *Session) setPhase(kind phaseKind, candidates map[string]struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.phase = phase{
+		seq:        s.phase.seq + 1,
+		kind:       kind,
+		candidates: candidates,
+		acted:      map[string]struct{}{},
+	}
+}
+
+func (s *Session) markActed(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.phase.acted[username] = struct{}{}
+}
+
+// kill eliminates the player, reason is either "vote" or "kill". The game
+// loop reads alive without locking since it is the only writer. The player
+// keeps watching the game as an omniscient spectator.
+func (s *Session) kill(username, reason string) {
+	s.mu.Lock()
+	delete(s.alive, username)
+	s.mu.Unlock()
+
+	s.send(s.user(username), s.spectateNotification(true))
+
+	s.emit(Event{
+		Type:     EventPlayerEliminated,
+		Username: username,
+		Reason:   reason,
+	})
+}
@@ -0,0 +1,230 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mcherdakov/soa-mafia/server/internal/generated/proto"
+)
+
+type TieRule int
+
+const (
+	// TieNoElimination keeps everyone alive when the vote is tied.
+	TieNoElimination TieRule = iota
+	// TieRunoff holds a second vote among the tied candidates. If the runoff
+	// is tied as well, nobody is eliminated.
+	TieRunoff
+	// TieRandom eliminates a random tied candidate and announces it.
+	TieRandom
+)
+
+var tieRuleNames = map[string]TieRule{
+	"none":   TieNoElimination,
+	"runoff": TieRunoff,
+	"random": TieRandom,
+}
+
+func (r TieRule) String() string {
+	for name, rule := range tieRuleNames {
+		if rule == r {
+			return name
+		}
+	}
+
+	return "unknown"
+}
+
+// Set implements flag.Value.
+func (r *TieRule) Set(name string) error {
+	rule, ok := tieRuleNames[name]
+	if !ok {
+		return fmt.Errorf("unknown tie rule %q, expected one of: none, runoff, random", name)
+	}
+
+	*r = rule
+
+	return nil
+}
+
+type voteOutcome struct {
+	votedOut *string
+	votes    []*proto.Vote
+	// runoffVotes is set if the tie was resolved by a runoff.
+	runoffVotes []*proto.Vote
+	tied        []string
+	randomPick  bool
+}
+
+// finalVotes is the tally that decided the outcome.
+func (o voteOutcome) finalVotes() []*proto.Vote {
+	if o.runoffVotes != nil {
+		return o.runoffVotes
+	}
+
+	return o.votes
+}
+
+// dayVote runs the day vote and resolves ties according to the session tie
+// rule.
+func (s *Session) dayVote(deadline time.Time) voteOutcome {
+	votes := s.awaitVote(deadline, nil)
+	outcome := voteOutcome{votes: votes}
+
+	leaders := voteLeaders(votes)
+
+	switch {
+	case len(leaders) == 0:
+		return outcome
+	case len(leaders) == 1:
+		outcome.votedOut = &leaders[0]
+		return outcome
+	}
+
+	outcome.tied = leaders
+
+	switch s.cfg.TieRule {
+	case TieNoElimination:
+	case TieRandom:
+		outcome.votedOut = &leaders[s.rng.Intn(len(leaders))]
+		outcome.randomPick = true
+	case TieRunoff:
+		runoffDeadline := s.clock.Now().Add(s.cfg.VoteTimeout)
+
+		candidates := make(map[string]struct{}, len(leaders))
+		for _, username := range leaders {
+			candidates[username] = struct{}{}
+		}
+
+		s.setPhase(phaseVote, candidates)
+		s.emit(Event{
+			Type:       EventPhaseChanged,
+			Phase:      "runoff",
+			Candidates: leaders,
+		})
+
+		runoff := &proto.Notifications{
+			Notification: &proto.Notifications_Runoff{
+				Runoff: &proto.RunoffNotification{
+					Candidates: leaders,
+					Votes:      votes,
+					Deadline:   runoffDeadline.UnixMilli(),
+				},
+			},
+		}
+
+		s.broadcastPhase(runoff)
+
+		outcome.runoffVotes = s.awaitVote(runoffDeadline, candidates)
+
+		runoffLeaders := voteLeaders(outcome.runoffVotes)
+		if len(runoffLeaders) == 1 {
+			outcome.votedOut = &runoffLeaders[0]
+			outcome.tied = nil
+		} else if len(runoffLeaders) > 1 {
+			outcome.tied = runoffLeaders
+		}
+	}
+
+	return outcome
+}
+
+// awaitVote collects votes from alive players until everyone has voted or the
+// deadline passes. Players who did not vote abstain. When candidates is not
+// nil, only votes for those players count.
+func (s *Session) awaitVote(deadline time.Time, candidates map[string]struct{}) []*proto.Vote {
+	votes := make(map[string]*string, len(s.alive))
+
+	timeout := s.clock.After(deadline.Sub(s.clock.Now()))
+
+	for {
+		cmd, ok := s.nextCommand(timeout, func() bool {
+			return !s.waitingForAny(func(username string) bool {
+				_, voted := votes[username]
+				return !voted
+			})
+		})
+		if !ok {
+			break
+		}
+
+		vote, ok := cmd.Cmd.GetCommand().(*proto.Commands_VoteCommand)
+		if !ok {
+			s.ignore(cmd)
+			continue
+		}
+
+		if _, ok := s.alive[cmd.Username]; !ok {
+			s.ignore(cmd)
+			continue
+		}
+
+		if _, ok := votes[cmd.Username]; ok {
+			s.ignore(cmd)
+			continue
+		}
+
+		if vote.VoteCommand.Abstain {
+			votes[cmd.Username] = nil
+			s.markActed(cmd.Username)
+
+			continue
+		}
+
+		target := vote.VoteCommand.Username
+		if _, ok := s.alive[target]; !ok {
+			s.ignore(cmd)
+			continue
+		}
+
+		if _, ok := candidates[target]; candidates != nil && !ok {
+			s.ignore(cmd)
+			continue
+		}
+
+		votes[cmd.Username] = &target
+		s.markActed(cmd.Username)
+	}
+
+	tally := make([]*proto.Vote, 0, len(s.alive))
+	for _, voter := range s.makeRemaining() {
+		tally = append(tally, &proto.Vote{
+			Voter:    voter,
+			Username: votes[voter],
+		})
+	}
+
+	sort.Slice(tally, func(i, j int) bool {
+		return tally[i].Voter < tally[j].Voter
+	})
+
+	return tally
+}
+
+// voteLeaders returns the players who got the most votes, sorted by name.
+func voteLeaders(votes []*proto.Vote) []string {
+	count := map[string]int{}
+	for _, vote := range votes {
+		if vote.Username != nil {
+			count[*vote.Username]++
+		}
+	}
+
+	curMax := 0
+	leaders := []string{}
+
+	for username, cnt := range count {
+		switch {
+		case cnt > curMax:
+			curMax = cnt
+			leaders = []string{username}
+		case cnt == curMax:
+			leaders = append(leaders, username)
+		}
+	}
+
+	sort.Strings(leaders)
+
+	return leaders
+}